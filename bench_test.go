@@ -1,6 +1,7 @@
 package gog
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"testing"
@@ -60,6 +61,18 @@ import (
 // Benchmark/Split-4              	       2	 813958700 ns/op	 8520072 B/op	   70906 allocs/op
 // Benchmark/New-4                	       1	1291804152 ns/op	 4608328 B/op	   51161 allocs/op
 // Benchmark/Triangulation-4      	     147	   7875483 ns/op	  311667 B/op	    3905 allocs/op
+//
+// cpu: Intel(R) Xeon(R) Processor
+// Benchmark/LineLine3d         	15846302	        76.31 ns/op	       0 B/op	       0 allocs/op
+// Benchmark/LineLine           	 2637386	       487.3 ns/op	      48 B/op	       3 allocs/op
+// Benchmark/ArcSplitNoPoint    	 1710128	       677.6 ns/op	     160 B/op	       2 allocs/op
+// Benchmark/ArcSplitPoint      	 2002552	       611.2 ns/op	     144 B/op	       2 allocs/op
+// Benchmark/Split              	       2	 576289133 ns/op	 9043520 B/op	   79939 allocs/op
+// Benchmark/New                	       2	 762374878 ns/op	 4482352 B/op	   50398 allocs/op
+// Benchmark/NewRandom          	       2	 749691959 ns/op	15050836 B/op	  110218 allocs/op
+// Benchmark/Triangulation      	     259	   4587990 ns/op	  339568 B/op	    4443 allocs/op
+// Benchmark/MarshalBinary      	      56	  18037054 ns/op	12255264 B/op	       7 allocs/op
+// Benchmark/JSON               	       3	 427953975 ns/op	171399901 B/op	     198 allocs/op
 func Benchmark(b *testing.B) {
 	b.Run("LineLine3d", func(b *testing.B) {
 		pps := []Point3d{
@@ -189,6 +202,15 @@ func Benchmark(b *testing.B) {
 			new()
 		}
 	})
+	b.Run("NewRandom", func(b *testing.B) {
+		model := randomModel(10000)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			if _, err := New(model); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 	b.Run("Triangulation", func(b *testing.B) {
 		pps := []Point{
 			{X: 1, Y: 1}, // 0
@@ -218,8 +240,12 @@ func Benchmark(b *testing.B) {
 				}
 				dist = math.Min(dist, math.Abs(xmax-xmin)/10.0)
 			}
-			model.Intersection()
-			model.Split(dist)
+			if err := model.Intersection(); err != nil {
+				b.Fatal(err)
+			}
+			if err := model.Split(dist); err != nil {
+				b.Fatal(err)
+			}
 			model.ArcsToLines()
 			mesh, err := New(model)
 			if err != nil {
@@ -243,4 +269,32 @@ func Benchmark(b *testing.B) {
 			}
 		}
 	})
+	b.Run("MarshalBinary", func(b *testing.B) {
+		m := gridModel(300)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			data, err := m.MarshalBinary()
+			if err != nil {
+				b.Fatal(err)
+			}
+			var r Model
+			if err = r.UnmarshalBinary(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("JSON", func(b *testing.B) {
+		m := gridModel(300)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			var buf bytes.Buffer
+			if err := m.Encode(&buf, JSONIndent); err != nil {
+				b.Fatal(err)
+			}
+			var r Model
+			if err := r.Decode(&buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
 }
@@ -0,0 +1,187 @@
+package gog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// Binary format of model:
+//
+//	magic    - 4 bytes `GOGM`
+//	version  - uvarint, version of binary format
+//	points   - uvarint amount and coordinates X, Y as little-endian float64
+//	lines    - uvarint amount and all indexes with tags as varint
+//	arcs     - uvarint amount and all indexes with tags as varint
+//	triangle - uvarint amount and all indexes with tags as varint
+//	quadrs   - uvarint amount and all indexes with tags as varint
+//	checksum - 4 bytes little-endian CRC-32 (IEEE) of all previous bytes
+
+// binaryMagic is first bytes of model in binary format
+var binaryMagic = []byte("GOGM")
+
+// BinaryVersion is version of binary format of model
+const BinaryVersion = 1
+
+// MarshalBinary return model in compact binary format.
+// Points are stored without losing of precision.
+func (m Model) MarshalBinary() (data []byte, err error) {
+	var (
+		buf = bytes.NewBuffer(make([]byte, 0, 4+16*len(m.Points)+
+			3*len(m.Lines)+4*len(m.Arcs)+4*len(m.Triangles)+5*len(m.Quadrs)+16))
+		tmp [binary.MaxVarintLen64]byte
+	)
+	uvarint := func(v int) {
+		buf.Write(tmp[:binary.PutUvarint(tmp[:], uint64(v))])
+	}
+	varint := func(vs ...int) {
+		for _, v := range vs {
+			buf.Write(tmp[:binary.PutVarint(tmp[:], int64(v))])
+		}
+	}
+	buf.Write(binaryMagic)
+	uvarint(BinaryVersion)
+	uvarint(len(m.Points))
+	for _, p := range m.Points {
+		binary.LittleEndian.PutUint64(tmp[:8], math.Float64bits(p.X))
+		buf.Write(tmp[:8])
+		binary.LittleEndian.PutUint64(tmp[:8], math.Float64bits(p.Y))
+		buf.Write(tmp[:8])
+	}
+	uvarint(len(m.Lines))
+	for _, v := range m.Lines {
+		varint(v[:]...)
+	}
+	uvarint(len(m.Arcs))
+	for _, v := range m.Arcs {
+		varint(v[:]...)
+	}
+	uvarint(len(m.Triangles))
+	for _, v := range m.Triangles {
+		varint(v[:]...)
+	}
+	uvarint(len(m.Quadrs))
+	for _, v := range m.Quadrs {
+		varint(v[:]...)
+	}
+	binary.LittleEndian.PutUint32(tmp[:4], crc32.ChecksumIEEE(buf.Bytes()))
+	buf.Write(tmp[:4])
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary read model from binary format of `MarshalBinary`
+func (m *Model) UnmarshalBinary(data []byte) (err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("UnmarshalBinary")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if len(data) < len(binaryMagic)+4 || !bytes.Equal(data[:len(binaryMagic)], binaryMagic) {
+		err = fmt.Errorf("data is not model in binary format")
+		return
+	}
+	body := data[:len(data)-4]
+	if sum := binary.LittleEndian.Uint32(data[len(data)-4:]); sum != crc32.ChecksumIEEE(body) {
+		err = fmt.Errorf("not valid checksum")
+		return
+	}
+	body = body[len(binaryMagic):]
+
+	uvarint := func() (v int, err error) {
+		u, n := binary.Uvarint(body)
+		if n <= 0 || uint64(len(body)) < u {
+			// amount of values cannot be more then amount of bytes
+			return 0, fmt.Errorf("not valid amount of values")
+		}
+		body = body[n:]
+		return int(u), nil
+	}
+	varint := func(vs []int) error {
+		for i := range vs {
+			v, n := binary.Varint(body)
+			if n <= 0 {
+				return fmt.Errorf("not valid index")
+			}
+			body = body[n:]
+			vs[i] = int(v)
+		}
+		return nil
+	}
+
+	version, err := uvarint()
+	if err != nil {
+		return
+	}
+	if version != BinaryVersion {
+		err = fmt.Errorf("not supported version of binary format: %d", version)
+		return
+	}
+	var r Model
+	// points
+	n, err := uvarint()
+	if err != nil {
+		return
+	}
+	if len(body) < 16*n {
+		err = fmt.Errorf("not enough data for %d points", n)
+		return
+	}
+	r.Points = make([]Point, n)
+	for i := range r.Points {
+		r.Points[i].X = math.Float64frombits(binary.LittleEndian.Uint64(body[:8]))
+		r.Points[i].Y = math.Float64frombits(binary.LittleEndian.Uint64(body[8:16]))
+		body = body[16:]
+	}
+	// lines
+	if n, err = uvarint(); err != nil {
+		return
+	}
+	r.Lines = make([][3]int, n)
+	for i := range r.Lines {
+		if err = varint(r.Lines[i][:]); err != nil {
+			return
+		}
+	}
+	// arcs
+	if n, err = uvarint(); err != nil {
+		return
+	}
+	r.Arcs = make([][4]int, n)
+	for i := range r.Arcs {
+		if err = varint(r.Arcs[i][:]); err != nil {
+			return
+		}
+	}
+	// triangles
+	if n, err = uvarint(); err != nil {
+		return
+	}
+	r.Triangles = make([][4]int, n)
+	for i := range r.Triangles {
+		if err = varint(r.Triangles[i][:]); err != nil {
+			return
+		}
+	}
+	// quadrs
+	if n, err = uvarint(); err != nil {
+		return
+	}
+	r.Quadrs = make([][5]int, n)
+	for i := range r.Quadrs {
+		if err = varint(r.Quadrs[i][:]); err != nil {
+			return
+		}
+	}
+	if len(body) != 0 {
+		err = fmt.Errorf("unexpected %d bytes at the end", len(body))
+		return
+	}
+	*m = r
+	return
+}
@@ -0,0 +1,99 @@
+package gog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"testing"
+)
+
+// gridModel return model with triangles and quadrs on grid size x size
+func gridModel(size int) (m Model) {
+	index := func(i, j int) int { return i*(size+1) + j }
+	for i := 0; i <= size; i++ {
+		for j := 0; j <= size; j++ {
+			m.Points = append(m.Points, Point{X: float64(i) / 3.0, Y: float64(j) / 7.0})
+		}
+	}
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if (i+j)%2 == 0 {
+				m.Quadrs = append(m.Quadrs, [5]int{
+					index(i, j), index(i+1, j), index(i+1, j+1), index(i, j+1), i % 5,
+				})
+				continue
+			}
+			m.Triangles = append(m.Triangles,
+				[4]int{index(i, j), index(i+1, j), index(i+1, j+1), j % 3},
+				[4]int{index(i, j), index(i+1, j+1), index(i, j+1), Removed},
+			)
+		}
+	}
+	for i := 0; i < size; i++ {
+		m.Lines = append(m.Lines, [3]int{index(i, 0), index(i+1, 0), -1})
+	}
+	m.Arcs = append(m.Arcs, [4]int{0, 1, 2, 100000})
+	return
+}
+
+func TestMarshalBinary(t *testing.T) {
+	m := gridModel(20)
+	m.Points = append(m.Points, Point{X: math.Pi, Y: -math.MaxFloat64})
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := m.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("size of binary: %d, size of JSON: %d", len(data), len(js))
+	if len(js) < 3*len(data) {
+		t.Errorf("binary format is not compact")
+	}
+	var r Model
+	if err := r.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprintf("%#v", r.Points) != fmt.Sprintf("%#v", m.Points) {
+		t.Errorf("not same points")
+	}
+	if r.String() != m.String() {
+		t.Errorf("not same models")
+	}
+}
+
+func TestUnmarshalBinaryError(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{1, 1}, 2)
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sign return data with correct checksum
+	sign := func(body []byte) []byte {
+		body = append([]byte{}, body...)
+		return binary.LittleEndian.AppendUint32(body, crc32.ChecksumIEEE(body))
+	}
+	body := data[:len(data)-4]
+	for i, data := range [][]byte{
+		nil,
+		[]byte("GOGM"),
+		[]byte("ABCD1234"),
+		append(append([]byte{}, body...), 0, 0, 0, 0),
+		sign(append([]byte("GOGM"), 2)),
+		sign(append([]byte("GOGM"), 1, 100)),
+		sign(body[:len(body)-1]),
+		sign(append(append([]byte{}, body...), 0)),
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var r Model
+			if err := r.UnmarshalBinary(data); err == nil {
+				t.Fatalf("error is not found")
+			} else {
+				t.Logf("%v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Command gog is command-line tool for meshing pipelines of package gog.
+//
+// Usage:
+//
+//	gog mesh    -in model.json -out mesh.json [-split 0.2] [-mesh-split 0.1] [-no-smooth]
+//	gog convert -in model.json -out model.msh [-msh 4.1]
+//	gog check   -in model.json [-mesh]
+//	gog info    -in model.json
+//
+// Format of file is defined by extension:
+//
+//	.json, .json.gz, .gogb - model in formats of `Model.Encode`
+//	.dxf                   - read lines, arcs, circles and write all elements
+//	.msh                   - gmsh file format version 2.2 or 4.1
+//	.poly                  - Triangle file format
+//	.vtk, .vtu, .svg       - only write
+//
+// Command mesh triangulates only lines and arcs of model, triangles and
+// quadrs of input model are ignored.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Konstantin8105/gog"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// usage of command-line tool
+const usage = `Usage: gog <command> [flags]
+
+Commands:
+	mesh    - triangulation of lines and arcs of model,
+	          triangles and quadrs of input model are ignored
+	convert - convert model between formats
+	check   - check triangulation
+	info    - information about model
+
+For flags of command run: gog <command> -h
+`
+
+// run command with arguments and write result into out
+func run(args []string, out io.Writer) (err error) {
+	if len(args) == 0 {
+		fmt.Fprintf(out, "%s", usage)
+		return fmt.Errorf("command is not found")
+	}
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(out)
+	in := fs.String("in", "", "input filename")
+	switch args[0] {
+	case "mesh":
+		var (
+			output    = fs.String("out", "", "output filename")
+			split     = fs.Float64("split", 0, "maximal length of model lines before triangulation, if zero then not used")
+			meshSplit = fs.Float64("mesh-split", 0, "maximal length of triangle sides, if zero then not used")
+			noSmooth  = fs.Bool("no-smooth", false, "triangulation without smoothing")
+			msh       = fs.String("msh", "2.2", "version of gmsh output file: 2.2 or 4.1")
+		)
+		if err = fs.Parse(args[1:]); err != nil {
+			return
+		}
+		var m gog.Model
+		if m, err = read(*in); err != nil {
+			return
+		}
+		var r gog.Model
+		if r, err = triangulate(m, *split, *meshSplit, !*noSmooth); err != nil {
+			return
+		}
+		if err = write(*output, r, *msh); err != nil {
+			return
+		}
+		fmt.Fprintf(out, "%s", info(r))
+
+	case "convert":
+		var (
+			output = fs.String("out", "", "output filename")
+			msh    = fs.String("msh", "2.2", "version of gmsh output file: 2.2 or 4.1")
+		)
+		if err = fs.Parse(args[1:]); err != nil {
+			return
+		}
+		var m gog.Model
+		if m, err = read(*in); err != nil {
+			return
+		}
+		err = write(*output, m, *msh)
+
+	case "check":
+		state := fs.Bool("mesh", false, "input file is state of mesh in JSON format of `Mesh.MarshalJSON`")
+		if err = fs.Parse(args[1:]); err != nil {
+			return
+		}
+		var mesh *gog.Mesh
+		if *state {
+			var b []byte
+			if b, err = os.ReadFile(*in); err != nil {
+				return
+			}
+			mesh = new(gog.Mesh)
+			if err = json.Unmarshal(b, mesh); err != nil {
+				return
+			}
+			err = mesh.Check()
+		} else {
+			var m gog.Model
+			if m, err = read(*in); err != nil {
+				return
+			}
+			// NewFromTriangles run check of mesh
+			mesh, err = gog.NewFromTriangles(m)
+		}
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(out, "Triangulation is valid\n")
+
+	case "info":
+		if err = fs.Parse(args[1:]); err != nil {
+			return
+		}
+		var m gog.Model
+		if m, err = read(*in); err != nil {
+			return
+		}
+		fmt.Fprintf(out, "%s", info(m))
+
+	case "-h", "-help", "--help", "help":
+		fmt.Fprintf(out, "%s", usage)
+
+	default:
+		fmt.Fprintf(out, "%s", usage)
+		return fmt.Errorf("not valid command: %s", args[0])
+	}
+	return
+}
+
+// triangulate return triangulated model with materials as tags of
+// triangles by pipeline:
+// Intersection, Split, New, Delanay, Split, Smooth, Materials.
+// Triangles and quadrs of model are ignored.
+func triangulate(m gog.Model, split, meshSplit float64, smooth bool) (r gog.Model, err error) {
+	if err = m.Intersection(); err != nil {
+		return
+	}
+	if 0 < split {
+		if err = m.Split(split); err != nil {
+			return
+		}
+	}
+	m.ArcsToLines()
+	m.Triangles = nil
+	m.Quadrs = nil
+	mesh, err := gog.New(m)
+	if err != nil {
+		return
+	}
+	if err = mesh.Delanay(); err != nil {
+		return
+	}
+	if 0 < meshSplit {
+		if err = mesh.Split(meshSplit); err != nil {
+			return
+		}
+	}
+	if smooth {
+		if err = mesh.Smooth(); err != nil {
+			return
+		}
+	}
+	if err = mesh.Materials(); err != nil {
+		return
+	}
+	r.Get(mesh)
+	return
+}
+
+// extension return extension of filename
+func extension(filename string) string {
+	filename = strings.ToLower(filename)
+	if strings.HasSuffix(filename, ".json.gz") {
+		return ".json.gz"
+	}
+	if index := strings.LastIndex(filename, "."); 0 <= index {
+		return filename[index:]
+	}
+	return ""
+}
+
+// dxfTag return tag from name of layer as last integer in name.
+// For example: tag of layer `lines+1` is 1.
+// Layers of labels and convex are ignored.
+func dxfTag(layer string) (tag int, ok bool) {
+	if strings.HasPrefix(layer, "labels_") || layer == "convex" {
+		return 0, false
+	}
+	index := strings.LastIndexFunc(layer, func(r rune) bool {
+		return (r < '0' || '9' < r) && r != '+' && r != '-'
+	})
+	if tag, err := strconv.Atoi(layer[index+1:]); err == nil {
+		return tag, true
+	}
+	return 0, true
+}
+
+// read model from file
+func read(filename string) (m gog.Model, err error) {
+	if filename == "" {
+		err = fmt.Errorf("input filename is empty")
+		return
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	switch ext := extension(filename); ext {
+	case ".json", ".json.gz", ".gogb":
+		err = m.Decode(f)
+	case ".dxf":
+		m, _, err = gog.ReadDxf(f, dxfTag)
+	case ".msh":
+		m, err = gog.ReadMsh(f)
+	case ".poly":
+		m, _, err = gog.ReadPoly(f, nil)
+	default:
+		err = fmt.Errorf("not supported format of input file: `%s`", ext)
+	}
+	return
+}
+
+// write model into file
+func write(filename string, m gog.Model, msh string) (err error) {
+	if filename == "" {
+		return fmt.Errorf("output filename is empty")
+	}
+	var buf bytes.Buffer
+	switch ext := extension(filename); ext {
+	case ".json":
+		err = m.Encode(&buf, gog.JSONIndent)
+	case ".json.gz":
+		err = m.Encode(&buf, gog.JSONGzip)
+	case ".gogb":
+		err = m.Encode(&buf, gog.Binary)
+	case ".dxf":
+		err = m.WriteDxf(&buf, gog.DxfOptions{})
+	case ".msh":
+		switch msh {
+		case "2.2":
+			err = m.WriteMsh(&buf, gog.Msh22)
+		case "4.1":
+			err = m.WriteMsh(&buf, gog.Msh41)
+		default:
+			err = fmt.Errorf("not supported version of gmsh file: %s", msh)
+		}
+	case ".poly":
+		err = m.WritePoly(&buf, nil)
+	case ".vtk":
+		err = m.WriteVTK(&buf, gog.VTKOptions{})
+	case ".vtu":
+		err = m.WriteVTU(&buf, gog.VTKOptions{})
+	case ".svg":
+		err = m.WriteSVG(&buf, gog.SVGOptions{})
+	default:
+		err = fmt.Errorf("not supported format of output file: `%s`", ext)
+	}
+	if err != nil {
+		return
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// info return information about model: amount of elements, border and
+// properties of tags
+func info(m gog.Model) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Points:    %d\n", len(m.Points))
+	fmt.Fprintf(&buf, "Lines:     %d\n", len(m.Lines))
+	fmt.Fprintf(&buf, "Arcs:      %d\n", len(m.Arcs))
+	fmt.Fprintf(&buf, "Triangles: %d\n", len(m.Triangles))
+	fmt.Fprintf(&buf, "Quadrs:    %d\n", len(m.Quadrs))
+	if 0 < len(m.Points) {
+		min, max := gog.BorderPoints2d(m.Points...)
+		fmt.Fprintf(&buf, "Border:    [%g, %g] - [%g, %g]\n", min.X, min.Y, max.X, max.Y)
+	}
+	// only elements with not negative tags
+	var c gog.Model
+	c.Points = m.Points
+	for _, l := range m.Lines {
+		if 0 <= l[2] {
+			c.Lines = append(c.Lines, l)
+		}
+	}
+	for _, tr := range m.Triangles {
+		if tr[0] != gog.Removed && 0 <= tr[3] {
+			c.Triangles = append(c.Triangles, tr)
+		}
+	}
+	length, area := c.TagProperty()
+	var tags []int
+	for tag := range length {
+		if length[tag] != 0 || area[tag] != 0 {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Ints(tags)
+	if 0 < len(tags) {
+		fmt.Fprintf(&buf, "Tags:\n")
+	}
+	for _, tag := range tags {
+		fmt.Fprintf(&buf, "%5d length: %.6g area: %.6g\n", tag, length[tag], area[tag])
+	}
+	return buf.String()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/gog"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	var m gog.Model
+	m.AddLine(gog.Point{X: 0, Y: 0}, gog.Point{X: 2, Y: 0}, 1)
+	m.AddLine(gog.Point{X: 2, Y: 0}, gog.Point{X: 2, Y: 2}, 1)
+	m.AddLine(gog.Point{X: 2, Y: 2}, gog.Point{X: 0, Y: 2}, 1)
+	m.AddLine(gog.Point{X: 0, Y: 2}, gog.Point{X: 0, Y: 0}, 1)
+	m.AddCircle(1, 1, 0.5, 2)
+	input := filepath.Join(dir, "model.json")
+	if err := m.Write(input); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	result := filepath.Join(dir, "mesh.json")
+	if err := run([]string{"mesh", "-in", input, "-out", result,
+		"-split", "0.5", "-mesh-split", "0.3"}, &out); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+	t.Logf("mesh:\n%s", out.String())
+
+	out.Reset()
+	if err := run([]string{"mesh", "-in", input, "-out", filepath.Join(dir, "raw.json"),
+		"-no-smooth"}, &out); err != nil {
+		t.Fatalf("%v\n%s", err, out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"check", "-in", result}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "valid") {
+		t.Errorf("not valid check: %s", out.String())
+	}
+
+	// check of mesh state
+	mesh, err := gog.New(gog.Model{Points: m.Points, Lines: m.Lines})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(mesh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := filepath.Join(dir, "state.json")
+	if err := os.WriteFile(state, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"check", "-mesh", "-in", state}, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	out.Reset()
+	if err := run([]string{"info", "-in", input}, &out); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("info:\n%s", out.String())
+	if !strings.Contains(out.String(), "Lines:") || !strings.Contains(out.String(), "Tags:") {
+		t.Errorf("not valid info: %s", out.String())
+	}
+
+	for _, name := range []string{
+		"model.json.gz", "model.gogb", "model.dxf", "model.msh", "model.vtk",
+		"model.vtu", "model.svg", "model.poly",
+	} {
+		t.Run(name, func(t *testing.T) {
+			from := result
+			if name == "model.poly" {
+				from = input
+				// poly file is without arcs
+				var c gog.Model
+				if err := c.Read(input); err != nil {
+					t.Fatal(err)
+				}
+				c.ArcsToLines()
+				from = filepath.Join(dir, "lines.json")
+				if err := c.Write(from); err != nil {
+					t.Fatal(err)
+				}
+			}
+			filename := filepath.Join(dir, name)
+			if err := run([]string{"convert", "-in", from, "-out", filename,
+				"-msh", "4.1"}, &out); err != nil {
+				t.Fatal(err)
+			}
+			if b, err := os.ReadFile(filename); err != nil || len(b) == 0 {
+				t.Fatalf("empty file: %v", err)
+			}
+			switch filepath.Ext(name) {
+			case ".vtk", ".vtu", ".svg":
+				return
+			}
+			if err := run([]string{"info", "-in", filename}, &out); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestRunError(t *testing.T) {
+	dir := t.TempDir()
+	var m gog.Model
+	m.AddLine(gog.Point{X: 0, Y: 0}, gog.Point{X: 2, Y: 0}, 1)
+	input := filepath.Join(dir, "model.json")
+	if err := m.Write(input); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{},
+		{"unknown"},
+		{"info"},
+		{"info", "-in", filepath.Join(dir, "not_exist.json")},
+		{"info", "-in", filepath.Join(dir, "model.txt")},
+		{"info", "-wrong"},
+		{"convert", "-in", input},
+		{"convert", "-in", input, "-out", filepath.Join(dir, "model.txt")},
+		{"convert", "-in", input, "-out", filepath.Join(dir, "model.msh"), "-msh", "3.0"},
+		{"mesh", "-in", input, "-out", filepath.Join(dir, "mesh.json")},
+		{"check", "-in", input},
+		{"check", "-in", input, "-mesh"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var out bytes.Buffer
+			if err := run(args, &out); err == nil {
+				t.Fatalf("error is not found")
+			}
+		})
+	}
+}
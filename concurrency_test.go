@@ -0,0 +1,144 @@
+package gog
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestConcurrency mesh many models in parallel and compare results with
+// sequential meshing. Test for race detector:
+//
+//	go test -race -run TestConcurrency
+func TestConcurrency(t *testing.T) {
+	size, err := NewMeshSize(func() (m Model) {
+		m.AddTriangle(Point{-2, -2}, Point{2, -2}, Point{2, 2}, 1)
+		m.AddTriangle(Point{-2, -2}, Point{2, 2}, Point{-2, 2}, 1)
+		return
+	}(), []float64{0.3, 0.6, 0.3, 0.6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	models := []struct {
+		name   string
+		model  Model
+		factor float64
+	}{
+		{
+			name: "rectangle",
+			model: func() (m Model) {
+				m.AddLine(Point{0, 0}, Point{2, 0}, 1)
+				m.AddLine(Point{2, 0}, Point{2, 1}, 1)
+				m.AddLine(Point{2, 1}, Point{0, 1}, 1)
+				m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+				m.AddLine(Point{0.5, 0.5}, Point{1.5, 0.6}, 2)
+				return
+			}(),
+			factor: 0.2,
+		},
+		{
+			name: "circle",
+			model: func() (m Model) {
+				m.AddLine(Point{-2, -2}, Point{2, -2}, 1)
+				m.AddLine(Point{2, -2}, Point{2, 2}, 1)
+				m.AddLine(Point{2, 2}, Point{-2, 2}, 1)
+				m.AddLine(Point{-2, 2}, Point{-2, -2}, 1)
+				m.AddCircle(0, 0, 1, 2)
+				m.AddLine(Point{-1.5, 0}, Point{1.5, 0}, 3)
+				return
+			}(),
+			factor: 0.5,
+		},
+		{
+			name:   "random",
+			model:  randomModel(200),
+			factor: 0.1,
+		},
+		{
+			name: "relative",
+			model: func() (m Model) {
+				m.SetConfig(Config{Tolerance: Tolerance{Relative: true}})
+				m.AddLine(Point{0, 0}, Point{1e-6, 0}, 1)
+				m.AddLine(Point{1e-6, 0}, Point{1e-6, 1e-6}, 1)
+				m.AddLine(Point{1e-6, 1e-6}, Point{0, 1e-6}, 1)
+				m.AddLine(Point{0, 1e-6}, Point{0, 0}, 1)
+				m.AddPoint(Point{0.3e-6, 0.6e-6})
+				return
+			}(),
+			factor: 0.2e-6,
+		},
+		{
+			name: "exact",
+			model: func() (m Model) {
+				m.SetConfig(Config{ExactPredicates: true})
+				for i := 0; i <= 10; i++ {
+					for j := 0; j <= 10; j++ {
+						m.AddPoint(Point{X: float64(i) / 10, Y: float64(j) / 10})
+					}
+				}
+				return
+			}(),
+			factor: 0.05,
+		},
+	}
+	// meshing of model
+	run := func(m Model, factor float64) (_ string, err error) {
+		mesh, err := New(m)
+		if err != nil {
+			return
+		}
+		if err = mesh.Split(factor); err != nil {
+			return
+		}
+		if err = mesh.Smooth(); err != nil {
+			return
+		}
+		if err = mesh.SplitSize(size); err != nil {
+			return
+		}
+		if err = mesh.Check(); err != nil {
+			return
+		}
+		return mesh.model.String(), nil
+	}
+	// sequential meshing
+	expect := make([]string, len(models))
+	inputs := make([]string, len(models))
+	for i, tc := range models {
+		inputs[i] = tc.model.String()
+		if expect[i], err = run(tc.model, tc.factor); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+	}
+	// parallel meshing of same models
+	const repeat = 4
+	var (
+		wg      sync.WaitGroup
+		results = make([]string, repeat*len(models))
+		errs    = make([]error, repeat*len(models))
+	)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tc := models[i%len(models)]
+			results[i], errs[i] = run(tc.model, tc.factor)
+		}(i)
+	}
+	wg.Wait()
+	for i := range results {
+		name := models[i%len(models)].name
+		if errs[i] != nil {
+			t.Errorf("%s: %v", name, errs[i])
+			continue
+		}
+		if results[i] != expect[i%len(models)] {
+			t.Errorf("%s: not same result of goroutine %d", name, i)
+		}
+	}
+	// input models are not changed
+	for i, tc := range models {
+		if s := tc.model.String(); s != inputs[i] {
+			t.Errorf("%s: input model is changed", tc.name)
+		}
+	}
+}
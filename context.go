@@ -0,0 +1,139 @@
+package gog
+
+import (
+	"context"
+	"slices"
+)
+
+// Progress is state of long operation for progress callback
+type Progress struct {
+	Stage string // name of operation stage
+	Count int    // amount of done iterations or elements
+	Total int    // total amount or zero, if total amount is unknown
+}
+
+// progressKey is key of progress callback in context
+type progressKey struct{}
+
+// WithProgress return context with progress callback for context-aware
+// operations like `NewContext` and `Mesh.SplitFuncContext`.
+// Callback is called in goroutine of operation.
+func WithProgress(ctx context.Context, f func(p Progress)) context.Context {
+	return context.WithValue(ctx, progressKey{}, f)
+}
+
+// progress is cancellation and progress callback of operation.
+// Nil progress is operation without context.
+type progress struct {
+	ctx context.Context
+	f   func(p Progress)
+}
+
+// newProgress return progress by context
+func newProgress(ctx context.Context) *progress {
+	p := &progress{ctx: ctx}
+	p.f, _ = ctx.Value(progressKey{}).(func(p Progress))
+	return p
+}
+
+// done return error of context, if operation is cancelled
+func (p *progress) done() error {
+	if p == nil {
+		return nil
+	}
+	return p.ctx.Err()
+}
+
+// report call progress callback and return error of context, if
+// operation is cancelled
+func (p *progress) report(stage string, count, total int) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	if p.f != nil {
+		p.f(Progress{Stage: stage, Count: count, Total: total})
+	}
+	return nil
+}
+
+// contextError return error of context instead of operation error, if
+// operation is cancelled
+func contextError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// withContext run operation of mesh with cancellation by context.
+// Mesh is restored, if operation return error.
+func (mesh *Mesh) withContext(ctx context.Context, f func() error) (err error) {
+	var (
+		model     = mesh.model.Copy()
+		points    = slices.Clone(mesh.Points)
+		triangles = slices.Clone(mesh.Triangles)
+	)
+	last := mesh.progress
+	mesh.progress = newProgress(ctx)
+	defer func() {
+		mesh.progress = last
+		if err == nil {
+			return
+		}
+		mesh.model = model
+		mesh.Points = points
+		mesh.Triangles = triangles
+		mesh.locator = locator{}
+		mesh.lines = lineIndex{}
+	}()
+	return contextError(ctx, f())
+}
+
+// NewContext is same as `New` with cancellation by context.
+// Error of context is returned, if operation is cancelled.
+// For progress callback see `WithProgress`.
+func NewContext(ctx context.Context, model Model) (mesh *Mesh, err error) {
+	mesh, err = newMesh(newProgress(ctx), model)
+	return mesh, contextError(ctx, err)
+}
+
+// IntersectionContext is same as `Model.Intersection` with cancellation
+// by context. Error of context is returned, if operation is cancelled.
+// Operation is run on copy of model, so model is not changed, if error
+// is returned.
+func (m *Model) IntersectionContext(ctx context.Context) error {
+	c := m.Copy()
+	if err := c.intersection(newProgress(ctx), newRtree); err != nil {
+		return contextError(ctx, err)
+	}
+	*m = c
+	return nil
+}
+
+// DelanayContext is same as `Mesh.Delanay` with cancellation by context.
+// Mesh is not changed, if error is returned.
+func (mesh *Mesh) DelanayContext(ctx context.Context, triIndexes ...int) error {
+	return mesh.withContext(ctx, func() error {
+		return mesh.Delanay(triIndexes...)
+	})
+}
+
+// SmoothContext is same as `Mesh.Smooth` with cancellation by context.
+// Mesh is not changed, if error is returned.
+func (mesh *Mesh) SmoothContext(ctx context.Context, pts ...int) error {
+	return mesh.withContext(ctx, func() error {
+		return mesh.Smooth(pts...)
+	})
+}
+
+// SplitFuncContext is same as `Mesh.SplitFunc` with cancellation by
+// context. Operation with not valid factorFunc is stopped by context.
+// Mesh is not changed, if error is returned.
+func (mesh *Mesh) SplitFuncContext(ctx context.Context, factorFunc func(p1, p2 Point) bool) error {
+	return mesh.withContext(ctx, func() error {
+		return mesh.SplitFunc(factorFunc)
+	})
+}
@@ -0,0 +1,155 @@
+package gog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	model := randomModel(100)
+	t.Run("progress", func(t *testing.T) {
+		stages := map[string]int{}
+		ctx := WithProgress(context.Background(), func(p Progress) {
+			stages[p.Stage]++
+		})
+		mesh, err := NewContext(ctx, model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = mesh.SplitFuncContext(ctx, func(p1, p2 Point) bool {
+			return 0.1 < Distance(p1, p2)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err = mesh.SmoothContext(ctx); err != nil {
+			t.Fatal(err)
+		}
+		for _, stage := range []string{
+			"Intersection",
+			"New: add points",
+			"New: add lines",
+			"Delanay",
+			"Split: triangle edges",
+			"Smooth",
+		} {
+			if stages[stage] == 0 {
+				t.Errorf("stage %q is not reported: %v", stage, stages)
+			}
+		}
+		// same result without context
+		exp, err := New(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = exp.Split(0.1); err != nil {
+			t.Fatal(err)
+		}
+		if err = exp.Smooth(); err != nil {
+			t.Fatal(err)
+		}
+		if mesh.model.String() != exp.model.String() {
+			t.Errorf("not same result with context")
+		}
+	})
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := NewContext(ctx, model); !errors.Is(err, context.Canceled) {
+			t.Errorf("not valid error: %v", err)
+		}
+		m := model.Copy()
+		if err := m.IntersectionContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("not valid error: %v", err)
+		}
+		mesh, err := New(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = mesh.DelanayContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("not valid error: %v", err)
+		}
+		if err = mesh.SmoothContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("not valid error: %v", err)
+		}
+	})
+	t.Run("cancel in progress", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		counter := 0
+		ctx = WithProgress(ctx, func(p Progress) {
+			if counter++; counter == 20 {
+				cancel()
+			}
+		})
+		mesh, err := New(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := fmt.Sprint(mesh.model, mesh.Points, mesh.Triangles)
+		if err = mesh.SplitFuncContext(ctx, func(p1, p2 Point) bool {
+			return 0.01 < Distance(p1, p2)
+		}); !errors.Is(err, context.Canceled) {
+			t.Errorf("not valid error: %v", err)
+		}
+		if counter != 20 {
+			t.Errorf("not valid amount of progress: %d", counter)
+		}
+		// mesh is not changed by cancelled operation
+		if after := fmt.Sprint(mesh.model, mesh.Points, mesh.Triangles); after != before {
+			t.Errorf("mesh is changed by cancelled operation")
+		}
+		if err = mesh.Split(0.1); err != nil {
+			t.Fatal(err)
+		}
+		exp, err := New(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = exp.Split(0.1); err != nil {
+			t.Fatal(err)
+		}
+		if mesh.model.String() != exp.model.String() {
+			t.Errorf("not same result after cancelled operation")
+		}
+		// model is not changed by cancelled intersection
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		ctx = WithProgress(ctx, func(p Progress) {
+			if p.Count == 1 {
+				// cancel after first iteration
+				cancel()
+			}
+		})
+		m := model.Copy()
+		m.AddLine(Point{-1, -1}, Point{2, 2}, 5)
+		before = m.String()
+		if err = m.IntersectionContext(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("not valid error: %v", err)
+		}
+		if m.String() != before {
+			t.Errorf("model is changed by cancelled intersection")
+		}
+	})
+	t.Run("deadline", func(t *testing.T) {
+		mesh, err := New(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		// infinite splitting
+		start := time.Now()
+		err = mesh.SplitFuncContext(ctx, func(p1, p2 Point) bool {
+			return true
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("not valid error: %v", err)
+		}
+		if d := time.Since(start); time.Second < d {
+			t.Errorf("too long cancellation: %v", d)
+		}
+	})
+}
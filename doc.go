@@ -0,0 +1,32 @@
+// Package gog is golang geometry library between point and segments,
+// models of points, lines, arcs and triangulation of models.
+//
+// # Concurrency
+//
+// Geometry functions are safe for concurrent use.
+//
+// Independent models and meshes may be used in different goroutines in
+// parallel. For example, functions `New`, `NewFromTriangles` and methods
+// `Mesh.Split`, `Mesh.Smooth`, `Mesh.Refine`. Input model of `New` and
+// `NewFromTriangles` is not changed, so one model may be triangulated
+// in many goroutines, if model is not changed at the same time.
+//
+// Operations `Model.Intersection`, `Model.Split` and `Mesh.SplitFunc`
+// use few goroutines for `Config.Workers` more 1. Result of operations
+// is same for any amount of workers.
+//
+// Model or mesh must not be used in different goroutines at the same time,
+// if one of them changes model or mesh. Assignment of model is not a copy
+// of model, because slices of points and elements are shared, for
+// independent model use `Model.Copy`.
+//
+// Global values `Eps`, `Debug`, `Log` and `ExactPredicates` are read by
+// all operations and must not be changed during operations. For specific
+// values for model or mesh use `Config`.
+//
+// # Errors
+//
+// Errors of operations are error trees with details of operation. Typical
+// errors like `ErrZeroLengthSegment` are checked by `errors.Is` and
+// details of error are available by `errors.As` with `ElementError`.
+package gog
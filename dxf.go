@@ -0,0 +1,655 @@
+package gog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// DxfOptions is options of dxf drawing
+type DxfOptions struct {
+	// WithoutConvex ignore layer "convex" with convex hull of model points
+	WithoutConvex bool
+
+	// PointLabels add index of points as TEXT entities
+	PointLabels bool
+
+	// ElementLabels add index of lines, arcs, triangles, quadrs as TEXT
+	// entities
+	ElementLabels bool
+
+	// TextHeight is height of TEXT entities. If value is zero, then
+	// height is calculated by size of model
+	TextHeight float64
+}
+
+// Dxf return string in dxf drawing format
+// https://images.autodesk.com/adsk/files/autocad_2012_pdf_dxf-reference_enu.pdf
+func (m Model) Dxf() string {
+	// create buffer
+	var buf bytes.Buffer
+	// writing into buffer is without errors
+	_ = m.WriteDxf(&buf, DxfOptions{})
+	return buf.String()
+}
+
+// dxfColor return color index of layer for tag.
+// Color index is integer value from 1 to 255.
+func dxfColor(tag int) int {
+	return 1 + (tag%255+255)%255
+}
+
+// WriteDxf write model in dxf drawing format with sections HEADER,
+// TABLES and ENTITIES. Layer of each tag have own color.
+// Arcs are written as ARC entities and if two arcs with same tag create
+// full circle, then CIRCLE entity is written.
+// https://images.autodesk.com/adsk/files/autocad_2012_pdf_dxf-reference_enu.pdf
+func (m Model) WriteDxf(w io.Writer, opts DxfOptions) (err error) {
+	// create buffer
+	var buf bytes.Buffer
+
+	// prepare layers
+	layers := map[string]int{}
+	layer := func(prefix string, tag int) (name string) {
+		name = fmt.Sprintf("%s%+2d", prefix, tag)
+		layers[name] = dxfColor(tag)
+		return
+	}
+
+	// text height
+	height := opts.TextHeight
+	if height <= 0 && 0 < len(m.Points) {
+		min, max := BorderPoints2d(m.Points...)
+		height = Distance(min, max) / 200.0
+		if height < Eps {
+			height = 1.0
+		}
+	}
+
+	// entities
+	var ents bytes.Buffer
+
+	line := func(st, en Point, layer string) {
+		fmt.Fprintf(&ents, "0\nLINE\n")
+		fmt.Fprintf(&ents, "8\n%s\n", layer) // layer
+		fmt.Fprintf(&ents, "10\n%f\n", st.X) // start point X
+		fmt.Fprintf(&ents, "20\n%f\n", st.Y) // start point Y
+		fmt.Fprintf(&ents, "30\n%f\n", 0.0)  // start point Z
+		fmt.Fprintf(&ents, "11\n%f\n", en.X) // end point X
+		fmt.Fprintf(&ents, "21\n%f\n", en.Y) // end point Y
+		fmt.Fprintf(&ents, "31\n%f\n", 0.0)  // end point Z
+	}
+
+	circle := func(xc, yc, r float64, layer string) {
+		fmt.Fprintf(&ents, "0\nCIRCLE\n")
+		fmt.Fprintf(&ents, "8\n%s\n", layer) // layer
+		fmt.Fprintf(&ents, "10\n%f\n", xc)   // center point X
+		fmt.Fprintf(&ents, "20\n%f\n", yc)   // center point Y
+		fmt.Fprintf(&ents, "30\n%f\n", 0.0)  // center point Z
+		fmt.Fprintf(&ents, "40\n%f\n", r)    // radius
+	}
+
+	arc := func(st, mi, en Point, layer string) {
+		switch Orientation(st, mi, en) {
+		case CollinearPoints:
+			line(st, en, layer)
+			return
+		case ClockwisePoints:
+			// arc in dxf is always counterclockwise
+			st, en = en, st
+		}
+		xc, yc, r, err := Arc(st, mi, en)
+		if err != nil {
+			// arc without circle is line
+			line(st, en, layer)
+			return
+		}
+		angle := func(p Point) float64 {
+			a := math.Atan2(p.Y-yc, p.X-xc) * 180.0 / math.Pi
+			if a < 0 {
+				a += 360.0
+			}
+			return a
+		}
+		fmt.Fprintf(&ents, "0\nARC\n")
+		fmt.Fprintf(&ents, "8\n%s\n", layer)      // layer
+		fmt.Fprintf(&ents, "10\n%f\n", xc)        // center point X
+		fmt.Fprintf(&ents, "20\n%f\n", yc)        // center point Y
+		fmt.Fprintf(&ents, "30\n%f\n", 0.0)       // center point Z
+		fmt.Fprintf(&ents, "40\n%f\n", r)         // radius
+		fmt.Fprintf(&ents, "50\n%f\n", angle(st)) // start angle
+		fmt.Fprintf(&ents, "51\n%f\n", angle(en)) // end angle
+	}
+
+	text := func(str string, p Point, layer string) {
+		layers[layer] = 7
+		fmt.Fprintf(&ents, "0\nTEXT\n")
+		fmt.Fprintf(&ents, "8\n%s\n", layer)   // layer
+		fmt.Fprintf(&ents, "10\n%f\n", p.X)    // insertion point X
+		fmt.Fprintf(&ents, "20\n%f\n", p.Y)    // insertion point Y
+		fmt.Fprintf(&ents, "30\n%f\n", 0.0)    // insertion point Z
+		fmt.Fprintf(&ents, "40\n%f\n", height) // text height
+		fmt.Fprintf(&ents, "1\n%s\n", str)     // text
+	}
+
+	// center return middle point of element
+	center := func(ps ...int) (c Point) {
+		for _, p := range ps {
+			c.X += m.Points[p].X
+			c.Y += m.Points[p].Y
+		}
+		c.X /= float64(len(ps))
+		c.Y /= float64(len(ps))
+		return
+	}
+
+	if 1 < len(m.Points) {
+		// draw convex
+		if !opts.WithoutConvex {
+			_, cps := ConvexHull(m.Points, true)
+			if 0 < len(cps) {
+				layers["convex"] = 8
+				for i := 1; i < len(cps); i++ {
+					line(cps[i-1], cps[i], "convex")
+				}
+				line(cps[len(cps)-1], cps[0], "convex")
+			}
+		}
+		// draw lines
+		for i := range m.Lines {
+			name := layer("lines", m.Lines[i][2])
+			line(m.Points[m.Lines[i][0]], m.Points[m.Lines[i][1]], name)
+			if opts.ElementLabels {
+				text(fmt.Sprintf("%d", i), center(m.Lines[i][:2]...), "labels_lines")
+			}
+		}
+		// draw arc
+		inCircle := make([]bool, len(m.Arcs))
+		for i := range m.Arcs {
+			for j := range m.Arcs {
+				if j <= i || inCircle[i] || inCircle[j] {
+					continue
+				}
+				a, b := m.Arcs[i], m.Arcs[j]
+				if a[3] != b[3] || a[0] != b[2] || a[2] != b[0] {
+					continue
+				}
+				if or := Orientation(m.Points[a[0]], m.Points[a[1]], m.Points[a[2]]); or == CollinearPoints ||
+					or != Orientation(m.Points[b[0]], m.Points[b[1]], m.Points[b[2]]) {
+					continue
+				}
+				// both arcs are on same circle
+				xa, ya, ra, erra := Arc(m.Points[a[0]], m.Points[a[1]], m.Points[a[2]])
+				xb, yb, rb, errb := Arc(m.Points[b[0]], m.Points[b[1]], m.Points[b[2]])
+				if erra != nil || errb != nil ||
+					Eps < Distance(Point{X: xa, Y: ya}, Point{X: xb, Y: yb}) ||
+					Eps < math.Abs(ra-rb) {
+					continue
+				}
+				inCircle[i] = true
+				inCircle[j] = true
+				circle(xa, ya, ra, layer("arcs", a[3]))
+			}
+		}
+		for i := range m.Arcs {
+			if !inCircle[i] {
+				name := layer("arcs", m.Arcs[i][3])
+				arc(m.Points[m.Arcs[i][0]], m.Points[m.Arcs[i][1]], m.Points[m.Arcs[i][2]], name)
+			}
+			if opts.ElementLabels {
+				text(fmt.Sprintf("%d", i), m.Points[m.Arcs[i][1]], "labels_arcs")
+			}
+		}
+		// draw triangles
+		for i := range m.Triangles {
+			name := layer("triangles", m.Triangles[i][3])
+			line(m.Points[m.Triangles[i][0]], m.Points[m.Triangles[i][1]], name)
+			line(m.Points[m.Triangles[i][1]], m.Points[m.Triangles[i][2]], name)
+			line(m.Points[m.Triangles[i][2]], m.Points[m.Triangles[i][0]], name)
+			if opts.ElementLabels {
+				text(fmt.Sprintf("%d", i), center(m.Triangles[i][:3]...), "labels_triangles")
+			}
+		}
+		// draw quadrs
+		for i := range m.Quadrs {
+			name := layer("quadrs", m.Quadrs[i][4])
+			line(m.Points[m.Quadrs[i][0]], m.Points[m.Quadrs[i][1]], name)
+			line(m.Points[m.Quadrs[i][1]], m.Points[m.Quadrs[i][2]], name)
+			line(m.Points[m.Quadrs[i][2]], m.Points[m.Quadrs[i][3]], name)
+			line(m.Points[m.Quadrs[i][3]], m.Points[m.Quadrs[i][0]], name)
+			if opts.ElementLabels {
+				text(fmt.Sprintf("%d", i), center(m.Quadrs[i][:4]...), "labels_quadrs")
+			}
+		}
+	}
+	// draw point labels
+	if opts.PointLabels {
+		for i := range m.Points {
+			text(fmt.Sprintf("%d", i), m.Points[i], "labels_points")
+		}
+	}
+
+	// header
+	fmt.Fprintf(&buf, "0\nSECTION\n")
+	fmt.Fprintf(&buf, "2\nHEADER\n")
+	fmt.Fprintf(&buf, "9\n$ACADVER\n")
+	fmt.Fprintf(&buf, "1\nAC1009\n")
+	fmt.Fprintf(&buf, "0\nENDSEC\n")
+
+	// tables
+	names := make([]string, 0, len(layers))
+	for name := range layers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(&buf, "0\nSECTION\n")
+	fmt.Fprintf(&buf, "2\nTABLES\n")
+	fmt.Fprintf(&buf, "0\nTABLE\n")
+	fmt.Fprintf(&buf, "2\nLAYER\n")
+	fmt.Fprintf(&buf, "70\n%d\n", len(names))
+	for _, name := range names {
+		fmt.Fprintf(&buf, "0\nLAYER\n")
+		fmt.Fprintf(&buf, "2\n%s\n", name)          // layer name
+		fmt.Fprintf(&buf, "70\n0\n")                // flags
+		fmt.Fprintf(&buf, "62\n%d\n", layers[name]) // color
+		fmt.Fprintf(&buf, "6\nCONTINUOUS\n")        // line type
+	}
+	fmt.Fprintf(&buf, "0\nENDTAB\n")
+	fmt.Fprintf(&buf, "0\nENDSEC\n")
+
+	// entities
+	fmt.Fprintf(&buf, "0\nSECTION\n")
+	fmt.Fprintf(&buf, "2\nENTITIES\n")
+	_, _ = ents.WriteTo(&buf)
+	fmt.Fprintf(&buf, "0\nENDSEC\n")
+
+	// end dxf
+	fmt.Fprintf(&buf, "0\nEOF\n")
+
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// dxfPair is group code and value of dxf file
+type dxfPair struct {
+	code  int
+	value string
+	line  int // line position in dxf file
+}
+
+// dxfEntity is entity of dxf file with all group codes
+type dxfEntity struct {
+	name  string
+	line  int // line position in dxf file
+	pairs []dxfPair
+}
+
+// layer return name of entity layer
+func (e dxfEntity) layer() string {
+	for _, p := range e.pairs {
+		if p.code == 8 {
+			return p.value
+		}
+	}
+	return "0"
+}
+
+// float return first float value with specific group code
+func (e dxfEntity) float(code int) (v float64, err error) {
+	for _, p := range e.pairs {
+		if p.code != code {
+			continue
+		}
+		return p.float()
+	}
+	return
+}
+
+// integer return first integer value with specific group code
+func (e dxfEntity) integer(code int) (v int, err error) {
+	for _, p := range e.pairs {
+		if p.code != code {
+			continue
+		}
+		return strconv.Atoi(p.value)
+	}
+	return
+}
+
+// extrusion return extrusion direction of entity, by default (0,0,1)
+func (e dxfEntity) extrusion() (n [3]float64, err error) {
+	n = [3]float64{0, 0, 1}
+	for _, p := range e.pairs {
+		for k, code := range [...]int{210, 220, 230} {
+			if p.code != code {
+				continue
+			}
+			if n[k], err = p.float(); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func (p dxfPair) float() (v float64, err error) {
+	v, err = strconv.ParseFloat(p.value, 64)
+	if err != nil {
+		err = fmt.Errorf("line %d: not valid value of group code %d: %v",
+			p.line, p.code, err)
+	}
+	return
+}
+
+// dxfPairs return all group code and value pairs of dxf file
+func dxfPairs(r io.Reader) (pairs []dxfPair, err error) {
+	scanner := bufio.NewScanner(r)
+	var (
+		pos  int
+		code int
+		isGC = true // line with group code
+	)
+	for scanner.Scan() {
+		pos++
+		line := strings.TrimSpace(scanner.Text())
+		if isGC {
+			code, err = strconv.Atoi(line)
+			if err != nil {
+				err = fmt.Errorf("line %d: not valid group code: %v", pos, err)
+				return
+			}
+		} else {
+			pairs = append(pairs, dxfPair{code: code, value: line, line: pos - 1})
+		}
+		isGC = !isGC
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	if !isGC {
+		err = fmt.Errorf("line %d: group code %d without value", pos, code)
+		return
+	}
+	return
+}
+
+// dxfEntities return all entities from section ENTITIES
+func dxfEntities(pairs []dxfPair) (entities []dxfEntity) {
+	inSection := false
+	for i := 0; i < len(pairs); i++ {
+		p := pairs[i]
+		switch {
+		case p.code == 0 && p.value == "SECTION":
+			if i+1 < len(pairs) && pairs[i+1].code == 2 &&
+				pairs[i+1].value == "ENTITIES" {
+				inSection = true
+				i++
+			}
+		case p.code == 0 && p.value == "ENDSEC":
+			inSection = false
+		case !inSection:
+			// ignore other sections
+		case p.code == 0:
+			entities = append(entities, dxfEntity{name: p.value, line: p.line})
+		case 0 < len(entities):
+			entities[len(entities)-1].pairs = append(entities[len(entities)-1].pairs, p)
+		}
+	}
+	return
+}
+
+// dxfVertex is point of polyline with bulge factor to next point
+type dxfVertex struct {
+	p     Point
+	bulge float64
+}
+
+// dxfLwVertexes return vertexes of entity LWPOLYLINE
+func dxfLwVertexes(e dxfEntity) (vs []dxfVertex, err error) {
+	for _, p := range e.pairs {
+		switch p.code {
+		case 10:
+			var x float64
+			if x, err = p.float(); err != nil {
+				return
+			}
+			vs = append(vs, dxfVertex{p: Point{X: x}})
+		case 20, 42:
+			if len(vs) == 0 {
+				err = fmt.Errorf("line %d: group code %d before vertex", p.line, p.code)
+				return
+			}
+			var v float64
+			if v, err = p.float(); err != nil {
+				return
+			}
+			if p.code == 20 {
+				vs[len(vs)-1].p.Y = v
+			} else {
+				vs[len(vs)-1].bulge = v
+			}
+		}
+	}
+	return
+}
+
+// addDxfPolyline add lines and arcs of polyline into model.
+// Bulge is tangent of 1/4 the included angle for the arc segment,
+// positive bulge for counterclockwise arc.
+func (m *Model) addDxfPolyline(vs []dxfVertex, closed bool, tag int) {
+	size := len(vs) - 1
+	if closed {
+		size = len(vs)
+	}
+	for i := 0; i < size; i++ {
+		var (
+			st = vs[i].p
+			en = vs[(i+1)%len(vs)].p
+			b  = vs[i].bulge
+		)
+		if SamePoints(st, en) {
+			continue
+		}
+		if math.Abs(b) < Eps {
+			m.AddLine(st, en, tag)
+			continue
+		}
+		// middle point of arc is located on perpendicular to chord
+		// on distance of sagitta
+		mid := MiddlePoint(st, en)
+		mid.X += b / 2 * (en.Y - st.Y)
+		mid.Y -= b / 2 * (en.X - st.X)
+		m.AddArc(st, mid, en, tag)
+	}
+}
+
+// ReadDxf return model based on entities LINE, ARC, CIRCLE, LWPOLYLINE,
+// POLYLINE from section ENTITIES of dxf file.
+// Function `tag` convert layer name into tag of model, if function
+// return false, then all entities of layer are ignored.
+// All not supported entities are reported in slice `unsupported`.
+// Extrusion direction (0,0,-1) of mirrored entities is supported,
+// other extrusion directions are not supported.
+//
+// https://images.autodesk.com/adsk/files/autocad_2012_pdf_dxf-reference_enu.pdf
+func ReadDxf(r io.Reader, tag func(layer string) (tag int, ok bool)) (
+	m Model,
+	unsupported []string,
+	err error,
+) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("ReadDxf")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	pairs, err := dxfPairs(r)
+	if err != nil {
+		return
+	}
+	entities := dxfEntities(pairs)
+
+	et := eTree.New("entities")
+	for i := 0; i < len(entities); i++ {
+		e := entities[i]
+		if e.name == "SEQEND" {
+			continue
+		}
+		t, ok := tag(e.layer())
+		if !ok {
+			continue
+		}
+		// values of group codes
+		var vs [7]float64
+		for k, code := range [...]int{10, 20, 11, 21, 40, 50, 51} {
+			if vs[k], err = e.float(code); err != nil {
+				_ = et.Add(err)
+				err = nil
+			}
+		}
+		// Coordinates of entities CIRCLE, ARC, LWPOLYLINE and 2D POLYLINE
+		// are in object coordinate system. By arbitrary axis algorithm
+		// for extrusion direction (0,0,-1) axis X is mirrored.
+		var (
+			dst      = &m
+			local    Model
+			mirrored bool
+		)
+		switch e.name {
+		case "CIRCLE", "ARC", "LWPOLYLINE", "POLYLINE":
+			n, errn := e.extrusion()
+			if errn != nil {
+				_ = et.Add(errn)
+				continue
+			}
+			if Eps < math.Abs(n[0]) || Eps < math.Abs(n[1]) || n[2] == 0 {
+				unsupported = append(unsupported,
+					fmt.Sprintf("line %d: %s with extrusion direction %v",
+						e.line, e.name, n))
+				continue
+			}
+			if n[2] < 0 {
+				dst = &local
+				mirrored = true
+			}
+		}
+		switch e.name {
+		case "LINE":
+			st := Point{X: vs[0], Y: vs[1]}
+			en := Point{X: vs[2], Y: vs[3]}
+			if SamePoints(st, en) {
+				unsupported = append(unsupported,
+					fmt.Sprintf("line %d: zero length LINE", e.line))
+				continue
+			}
+			m.AddLine(st, en, t)
+
+		case "CIRCLE":
+			if vs[4] < Eps {
+				unsupported = append(unsupported,
+					fmt.Sprintf("line %d: zero radius CIRCLE", e.line))
+				continue
+			}
+			dst.AddCircle(vs[0], vs[1], vs[4], t)
+
+		case "ARC":
+			var (
+				xc, yc, r = vs[0], vs[1], vs[4]
+				from      = vs[5] * math.Pi / 180.0
+				to        = vs[6] * math.Pi / 180.0
+			)
+			// arc is always counterclockwise
+			for to <= from {
+				to += 2 * math.Pi
+			}
+			if r < Eps || to-from < Eps {
+				unsupported = append(unsupported,
+					fmt.Sprintf("line %d: zero length ARC", e.line))
+				continue
+			}
+			if 2*math.Pi-Eps < to-from {
+				dst.AddCircle(xc, yc, r, t)
+				continue
+			}
+			point := func(angle float64) Point {
+				return Point{
+					X: math.FMA(r, math.Cos(angle), xc),
+					Y: math.FMA(r, math.Sin(angle), yc),
+				}
+			}
+			dst.AddArc(point(from), point((from+to)/2.0), point(to), t)
+
+		case "LWPOLYLINE":
+			flag, errf := e.integer(70)
+			if errf != nil {
+				_ = et.Add(fmt.Errorf("line %d: %v", e.line, errf))
+				continue
+			}
+			vertexes, errv := dxfLwVertexes(e)
+			if errv != nil {
+				_ = et.Add(errv)
+				continue
+			}
+			dst.addDxfPolyline(vertexes, flag&1 != 0, t)
+
+		case "POLYLINE":
+			flag, errf := e.integer(70)
+			if errf != nil {
+				_ = et.Add(fmt.Errorf("line %d: %v", e.line, errf))
+				continue
+			}
+			// vertexes are located after polyline
+			var vertexes []dxfVertex
+			for ; i+1 < len(entities) && entities[i+1].name == "VERTEX"; i++ {
+				v := entities[i+1]
+				var x, y, b float64
+				for _, c := range []struct {
+					code  int
+					value *float64
+				}{{10, &x}, {20, &y}, {42, &b}} {
+					if *c.value, err = v.float(c.code); err != nil {
+						_ = et.Add(err)
+						err = nil
+					}
+				}
+				vertexes = append(vertexes, dxfVertex{p: Point{X: x, Y: y}, bulge: b})
+			}
+			// 16 - 3D polygon mesh, 64 - polyface mesh
+			if flag&(16|64) != 0 {
+				unsupported = append(unsupported,
+					fmt.Sprintf("line %d: POLYLINE mesh with flag %d", e.line, flag))
+				continue
+			}
+			// 8 - 3D polyline in world coordinate system
+			if flag&8 != 0 {
+				mirrored = false
+			}
+			dst.addDxfPolyline(vertexes, flag&1 != 0, t)
+
+		default:
+			unsupported = append(unsupported,
+				fmt.Sprintf("line %d: entity %s on layer `%s`", e.line, e.name, e.layer()))
+		}
+		if dst == &local {
+			if mirrored {
+				for k := range local.Points {
+					local.Points[k].X = -local.Points[k].X
+				}
+			}
+			m.AddModel(local)
+		}
+	}
+	if et.IsError() {
+		err = errorTree{et}
+		return
+	}
+	return
+}
@@ -0,0 +1,239 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestReadDxf(t *testing.T) {
+	entities := []string{
+		// line
+		"0", "LINE", "8", "1", "10", "0.0", "20", "0.0", "11", "4.0", "21", "0.0",
+		// circle
+		"0", "CIRCLE", "8", "2", "10", "10.0", "20", "10.0", "40", "1.0",
+		// arc
+		"0", "ARC", "8", "3", "10", "0.0", "20", "0.0", "40", "4.0",
+		"50", "0.0", "51", "90.0",
+		// lwpolyline with bulge
+		"0", "LWPOLYLINE", "8", "4", "90", "3", "70", "1",
+		"10", "20.0", "20", "0.0",
+		"10", "22.0", "20", "0.0", "42", "1.0",
+		"10", "22.0", "20", "2.0",
+		// polyline
+		"0", "POLYLINE", "8", "5", "66", "1", "70", "0",
+		"0", "VERTEX", "8", "5", "10", "30.0", "20", "0.0",
+		"0", "VERTEX", "8", "5", "10", "31.0", "20", "1.0",
+		"0", "VERTEX", "8", "5", "10", "32.0", "20", "0.0",
+		"0", "SEQEND", "8", "5",
+		// unsupported
+		"0", "TEXT", "8", "6", "10", "0.0", "20", "0.0", "1", "Hello",
+		// ignored layer
+		"0", "LINE", "8", "ignore", "10", "0.0", "20", "0.0", "11", "4.0", "21", "4.0",
+	}
+	var dxf []string
+	dxf = append(dxf, "0", "SECTION", "2", "HEADER", "0", "ENDSEC")
+	dxf = append(dxf, "0", "SECTION", "2", "ENTITIES")
+	dxf = append(dxf, entities...)
+	dxf = append(dxf, "0", "ENDSEC", "0", "EOF")
+
+	m, unsupported, err := ReadDxf(
+		strings.NewReader(strings.Join(dxf, "\n")),
+		func(layer string) (tag int, ok bool) {
+			tag, err := strconv.Atoi(layer)
+			return tag, err == nil
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s", m)
+	fmt.Fprintf(&buf, "Unsupported:\n")
+	for _, u := range unsupported {
+		fmt.Fprintf(&buf, "%s\n", u)
+	}
+	compare.Test(t, filepath.Join("testdata", "ReadDxf"), buf.Bytes())
+}
+
+func TestReadDxfExtrusion(t *testing.T) {
+	read := func(entities ...string) (m Model, unsupported []string) {
+		var dxf []string
+		dxf = append(dxf, "0", "SECTION", "2", "ENTITIES")
+		dxf = append(dxf, entities...)
+		dxf = append(dxf, "0", "ENDSEC", "0", "EOF")
+		m, unsupported, err := ReadDxf(
+			strings.NewReader(strings.Join(dxf, "\n")),
+			func(layer string) (tag int, ok bool) { return 1, true },
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	// entities with extrusion direction (0,0,-1)
+	m, unsupported := read(
+		"0", "CIRCLE", "10", "10.0", "20", "10.0", "40", "1.0",
+		"210", "0.0", "220", "0.0", "230", "-1.0",
+		"0", "ARC", "10", "2.0", "20", "0.0", "40", "1.0",
+		"50", "0.0", "51", "90.0", "230", "-1.0",
+		"0", "LWPOLYLINE", "90", "3", "70", "1", "230", "-1.0",
+		"10", "20.0", "20", "0.0",
+		"10", "22.0", "20", "0.0", "42", "1.0",
+		"10", "22.0", "20", "2.0",
+		// not supported extrusion direction
+		"0", "ARC", "10", "2.0", "20", "0.0", "40", "1.0",
+		"50", "0.0", "51", "90.0", "210", "1.0", "230", "0.0",
+	)
+	// same entities in world coordinate system
+	expect, _ := read(
+		"0", "CIRCLE", "10", "-10.0", "20", "10.0", "40", "1.0",
+		"0", "ARC", "10", "-2.0", "20", "0.0", "40", "1.0",
+		"50", "90.0", "51", "180.0",
+		"0", "LWPOLYLINE", "90", "3", "70", "1",
+		"10", "-20.0", "20", "0.0",
+		"10", "-22.0", "20", "0.0", "42", "-1.0",
+		"10", "-22.0", "20", "2.0",
+	)
+	if len(m.Arcs) != len(expect.Arcs) || len(m.Lines) != len(expect.Lines) {
+		t.Fatalf("not same models:\n%s\n%s", m, expect)
+	}
+	// arcs of same geometry
+	key := func(m Model, a [4]int) string {
+		ps := []Point{m.Points[a[0]], m.Points[a[1]], m.Points[a[2]]}
+		if ps[2].X < ps[0].X || (ps[2].X == ps[0].X && ps[2].Y < ps[0].Y) {
+			ps[0], ps[2] = ps[2], ps[0]
+		}
+		return fmt.Sprintf("%.6f", ps)
+	}
+	arcs := map[string]bool{}
+	for _, a := range expect.Arcs {
+		arcs[key(expect, a)] = true
+	}
+	for _, a := range m.Arcs {
+		if !arcs[key(m, a)] {
+			t.Errorf("not valid arc: %s", key(m, a))
+		}
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s", m)
+	fmt.Fprintf(&buf, "Unsupported:\n")
+	for _, u := range unsupported {
+		fmt.Fprintf(&buf, "%s\n", u)
+	}
+	compare.Test(t, filepath.Join("testdata", "ReadDxfExtrusion"), buf.Bytes())
+}
+
+func TestReadDxfModel(t *testing.T) {
+	var m Model
+	m.AddCircle(0, 0, 1, 1)
+	m.AddCircle(4, 0, 1, 4)
+	m.AddLine(Point{-1, 0}, Point{1, 0}, 2)
+	m.AddLine(Point{0, -1}, Point{0, 1}, 3)
+	if err := m.Intersection(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, unsupported, err := ReadDxf(strings.NewReader(m.Dxf()),
+		func(layer string) (tag int, ok bool) {
+			for _, prefix := range []string{"lines", "arcs"} {
+				if strings.HasPrefix(layer, prefix) {
+					tag, err := strconv.Atoi(strings.TrimPrefix(layer, prefix))
+					return tag, err == nil
+				}
+			}
+			return
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unsupported) != 0 {
+		t.Fatalf("unsupported entities: %v", unsupported)
+	}
+	// dxf file store coordinates with 6 digits after point
+	same := func(p0, p1 Point) bool {
+		return Distance(p0, p1) < 1e-5
+	}
+	if len(r.Lines) != len(m.Lines) {
+		t.Errorf("not same amount of lines: %d != %d", len(r.Lines), len(m.Lines))
+	}
+	for _, line := range m.Lines {
+		found := false
+		for _, rl := range r.Lines {
+			if same(m.Points[line[0]], r.Points[rl[0]]) &&
+				same(m.Points[line[1]], r.Points[rl[1]]) &&
+				line[2] == rl[2] {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("line is not found: %v", line)
+		}
+	}
+	if len(r.Arcs) != len(m.Arcs) {
+		t.Errorf("not same amount of arcs: %d != %d", len(r.Arcs), len(m.Arcs))
+	}
+	for _, arc := range m.Arcs {
+		found := false
+		for _, ra := range r.Arcs {
+			if arc[3] != ra[3] || !same(m.Points[arc[1]], r.Points[ra[1]]) {
+				continue
+			}
+			if (same(m.Points[arc[0]], r.Points[ra[0]]) && same(m.Points[arc[2]], r.Points[ra[2]])) ||
+				(same(m.Points[arc[0]], r.Points[ra[2]]) && same(m.Points[arc[2]], r.Points[ra[0]])) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("arc is not found: %v", arc)
+		}
+	}
+}
+
+func TestWriteDxf(t *testing.T) {
+	var m Model
+	m.AddCircle(0, 0, 1, 1)
+	m.AddArc(Point{2, 0}, Point{3, 1}, Point{4, 0}, 2)
+	m.AddLine(Point{-1, 0}, Point{1, 0}, 3)
+	m.AddTriangle(Point{5, 0}, Point{6, 0}, Point{5, 1}, 4)
+	m.Quadrs = append(m.Quadrs, [5]int{
+		m.AddPoint(Point{7, 0}),
+		m.AddPoint(Point{8, 0}),
+		m.AddPoint(Point{8, 1}),
+		m.AddPoint(Point{7, 1}),
+		5,
+	})
+	var buf bytes.Buffer
+	if err := m.WriteDxf(&buf, DxfOptions{
+		WithoutConvex: true,
+		PointLabels:   true,
+		ElementLabels: true,
+		TextHeight:    0.1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	compare.Test(t, filepath.Join("testdata", "WriteDxf"), buf.Bytes())
+}
+
+func TestReadDxfError(t *testing.T) {
+	for i, dxf := range []string{
+		"0\nSECTION\n2",
+		"zero\nSECTION",
+		"0\nSECTION\n2\nENTITIES\n0\nLINE\n10\nwrong\n0\nENDSEC\n0\nEOF",
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			_, _, err := ReadDxf(strings.NewReader(dxf),
+				func(layer string) (int, bool) { return 0, true })
+			if err == nil {
+				t.Fatalf("error is not found")
+			}
+			t.Logf("%v", err)
+		})
+	}
+}
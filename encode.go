@@ -0,0 +1,119 @@
+package gog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// ModelFormat is format of model serialization
+type ModelFormat int
+
+const (
+	// JSONIndent is indented JSON format, same as in `Model.JSON`
+	JSONIndent ModelFormat = iota
+
+	// JSONCompact is JSON format without indents
+	JSONCompact
+
+	// JSONGzip is JSON format without indents compressed by gzip
+	JSONGzip
+
+	// Binary is compact binary format, same as in `Model.MarshalBinary`
+	Binary
+)
+
+// ModelVersion is version of model schema in serialization.
+// Version is increased for any changes of schema.
+// Model without version have version zero.
+const ModelVersion = 1
+
+// modelFile is model with version of schema
+type modelFile struct {
+	Version int `json:"Version"`
+	Model
+}
+
+// Encode write model with version of schema in format
+func (m Model) Encode(w io.Writer, format ModelFormat) (err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("Encode")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	f := modelFile{Version: ModelVersion, Model: m}
+	switch format {
+	case JSONIndent:
+		enc := json.NewEncoder(w)
+		enc.SetIndent(" ", "\t")
+		err = enc.Encode(f)
+	case JSONCompact:
+		err = json.NewEncoder(w).Encode(f)
+	case JSONGzip:
+		zw := gzip.NewWriter(w)
+		if err = json.NewEncoder(zw).Encode(f); err != nil {
+			_ = zw.Close()
+			return
+		}
+		err = zw.Close()
+	case Binary:
+		var data []byte
+		if data, err = m.MarshalBinary(); err != nil {
+			return
+		}
+		_, err = w.Write(data)
+	default:
+		err = fmt.Errorf("not valid format: %d", format)
+	}
+	return
+}
+
+// Decode read model in any format of `Encode`. Format is detected
+// automatically. Models without version of schema are supported.
+func (m *Model) Decode(r io.Reader) (err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("Decode")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	br := bufio.NewReader(r)
+	r = br
+	// detect binary format by magic number
+	if magic, _ := br.Peek(len(binaryMagic)); bytes.Equal(magic, binaryMagic) {
+		var data []byte
+		if data, err = io.ReadAll(br); err != nil {
+			return
+		}
+		return m.UnmarshalBinary(data)
+	}
+	// detect gzip by magic number
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		var zr *gzip.Reader
+		if zr, err = gzip.NewReader(br); err != nil {
+			return
+		}
+		defer func() {
+			_ = zr.Close()
+		}()
+		r = zr
+	}
+	var f modelFile
+	if err = json.NewDecoder(r).Decode(&f); err != nil {
+		return
+	}
+	if ModelVersion < f.Version || f.Version < 0 {
+		err = fmt.Errorf("not supported version of model: %d", f.Version)
+		return
+	}
+	*m = f.Model
+	return
+}
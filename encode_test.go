@@ -0,0 +1,86 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	var m Model
+	m.AddCircle(0, 0, 1, 1)
+	m.AddLine(Point{-1, 0}, Point{1, 0}, 2)
+	m.AddTriangle(Point{5, 0}, Point{6, 0}, Point{5, 1}, 4)
+	m.Quadrs = append(m.Quadrs, [5]int{
+		m.AddPoint(Point{7, 0}),
+		m.AddPoint(Point{8, 0}),
+		m.AddPoint(Point{8, 1}),
+		m.AddPoint(Point{7, 1}),
+		5,
+	})
+	for _, format := range []ModelFormat{JSONIndent, JSONCompact, JSONGzip, Binary} {
+		t.Run(fmt.Sprintf("%d", format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := m.Encode(&buf, format); err != nil {
+				t.Fatal(err)
+			}
+			var r Model
+			if err := r.Decode(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if r.String() != m.String() {
+				t.Errorf("not same models:\n%s\n%s", r, m)
+			}
+		})
+	}
+	t.Run("file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "model.json")
+		if err := m.Write(filename); err != nil {
+			t.Fatal(err)
+		}
+		var r Model
+		if err := r.Read(filename); err != nil {
+			t.Fatal(err)
+		}
+		if r.String() != m.String() {
+			t.Errorf("not same models:\n%s\n%s", r, m)
+		}
+	})
+	t.Run("without version", func(t *testing.T) {
+		out, err := m.JSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r Model
+		if err := r.Decode(strings.NewReader(out)); err != nil {
+			t.Fatal(err)
+		}
+		if r.String() != m.String() {
+			t.Errorf("not same models:\n%s\n%s", r, m)
+		}
+	})
+}
+
+func TestDecodeError(t *testing.T) {
+	for i, str := range []string{
+		"",
+		"{",
+		"\x1f\x8b",
+		fmt.Sprintf(`{"Version":%d}`, ModelVersion+1),
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var m Model
+			if err := m.Decode(strings.NewReader(str)); err == nil {
+				t.Fatalf("error is not found")
+			} else {
+				t.Logf("%v", err)
+			}
+		})
+	}
+	var m Model
+	if err := m.Encode(&bytes.Buffer{}, ModelFormat(-1)); err == nil {
+		t.Errorf("error for not valid format is not found")
+	}
+}
@@ -0,0 +1,68 @@
+package gog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// Errors of model and mesh operations. Errors are usable with `errors.Is`.
+// Details of error are in `ElementError`, see `errors.As`.
+var (
+	// ErrZeroLengthSegment is error of line with zero length
+	ErrZeroLengthSegment = errors.New("zero length segment")
+
+	// ErrCollinearTriangle is error of triangle with collinear points
+	ErrCollinearTriangle = errors.New("collinear triangle")
+
+	// ErrPointOutside is error of point outside of triangulation
+	ErrPointOutside = errors.New("point outside of triangulation")
+
+	// ErrNotValidArc is error of arc, that cannot be split
+	ErrNotValidArc = errors.New("not valid arc")
+)
+
+// ElementError is error of model element with indexes and coordinates
+// of points
+type ElementError struct {
+	Err    error   // error of element, for example `ErrZeroLengthSegment`
+	Index  int     // index of element or -1 for point
+	Points []int   // indexes of points or nil, if points are not in model
+	Coords []Point // coordinates of points
+}
+
+// Error return description of element error
+func (e *ElementError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v", e.Err)
+	if 0 <= e.Index {
+		fmt.Fprintf(&sb, ": element %d", e.Index)
+	}
+	if 0 < len(e.Points) {
+		fmt.Fprintf(&sb, ": points %v", e.Points)
+	}
+	if 0 < len(e.Coords) {
+		fmt.Fprintf(&sb, ": coordinates %.9e", e.Coords)
+	}
+	return sb.String()
+}
+
+// Unwrap return error of element
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// errorTree is error tree usable with `errors.Is` and `errors.As`
+type errorTree struct {
+	*eTree.Tree
+}
+
+// Unwrap return all errors of error tree
+func (e errorTree) Unwrap() (errs []error) {
+	eTree.Walk(e.Tree, func(err error) {
+		errs = append(errs, err)
+	})
+	return
+}
@@ -0,0 +1,105 @@
+package gog
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	square := func() (m Model) {
+		m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+		m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+		m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+		m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+		return
+	}
+	t.Run("zero length segment", func(t *testing.T) {
+		m := square()
+		m.AddLine(Point{0.5, 0}, Point{0.5, 0}, 2)
+		c := m.Copy()
+		err := c.Intersection()
+		if !errors.Is(err, ErrZeroLengthSegment) {
+			t.Fatalf("not valid error: %v", err)
+		}
+		var ee *ElementError
+		if !errors.As(err, &ee) {
+			t.Fatalf("not element error: %v", err)
+		}
+		if ee.Index != 4 || ee.Coords[0] != (Point{0.5, 0}) {
+			t.Errorf("not valid element error: %v", ee)
+		}
+		// error in error tree
+		_, err = New(m)
+		if !errors.Is(err, ErrZeroLengthSegment) {
+			t.Errorf("not valid error: %v", err)
+		}
+	})
+	t.Run("split distance", func(t *testing.T) {
+		m := square()
+		if err := m.Split(0); err == nil {
+			t.Errorf("split by zero distance")
+		}
+		if len(m.Lines) != 4 {
+			t.Errorf("model is changed")
+		}
+	})
+	t.Run("collinear triangle", func(t *testing.T) {
+		var mesh Mesh
+		mesh.model.AddTriangle(Point{0, 0}, Point{1, 0}, Point{1, 1}, 1)
+		mesh.model.AddTriangle(Point{0, 0}, Point{0.5, 0.5}, Point{1, 1}, 1)
+		mesh.Triangles = make([][3]int, 2)
+		err := mesh.Clockwise()
+		if !errors.Is(err, ErrCollinearTriangle) {
+			t.Fatalf("not valid error: %v", err)
+		}
+		var ee *ElementError
+		if !errors.As(err, &ee) || ee.Index != 1 || len(ee.Coords) != 3 {
+			t.Errorf("not valid element error: %v", err)
+		}
+	})
+	t.Run("collinear arc", func(t *testing.T) {
+		arc := func() (m Model) {
+			m.Points = []Point{{0, 0}, {1, 0}, {2, 0}}
+			m.Arcs = [][4]int{{0, 1, 2, 1}}
+			return
+		}
+		for _, c := range []struct {
+			name string
+			f    func(m *Model) error
+		}{
+			{"Split", func(m *Model) error { return m.Split(0.1) }},
+			{"Intersection", func(m *Model) error { return m.Intersection() }},
+			{"SplitSize", func(m *Model) error { return m.SplitSize(ConstantSize(0.1)) }},
+		} {
+			t.Run(c.name, func(t *testing.T) {
+				m := arc()
+				err := c.f(&m)
+				if !errors.Is(err, ErrNotValidArc) {
+					t.Fatalf("not valid error: %v", err)
+				}
+				var ee *ElementError
+				if !errors.As(err, &ee) || ee.Index != 0 || len(ee.Coords) != 3 {
+					t.Errorf("not valid element error: %v", err)
+				}
+			})
+		}
+	})
+	t.Run("point outside", func(t *testing.T) {
+		mesh, err := New(square())
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := Point{2, 2}
+		if _, err = mesh.AddPoint(p, Fixed); !errors.Is(err, ErrPointOutside) {
+			t.Errorf("not valid error: %v", err)
+		}
+		_, err = mesh.GetMaterials(p)
+		var ee *ElementError
+		if !errors.As(err, &ee) || ee.Err != ErrPointOutside || ee.Coords[0] != p {
+			t.Errorf("not valid error: %v", err)
+		}
+		if err = mesh.Check(); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -1,7 +1,6 @@
 package gog
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -130,7 +129,8 @@ func Check(pps ...Point) error {
 }
 
 var (
-	// Eps is epsilon - precision of intersection
+	// Eps is epsilon - precision of intersection.
+	// For specific precision see `Tolerance`.
 	Eps = 1e-10
 )
 
@@ -140,9 +140,19 @@ func PointPoint(
 ) (
 	pi []Point,
 	stA, stB State,
+) {
+	return Tolerance{}.PointPoint(pt0, pt1)
+}
+
+// PointPoint is same as function `PointPoint` with tolerance
+func (t Tolerance) PointPoint(
+	pt0, pt1 Point,
+) (
+	pi []Point,
+	stA, stB State,
 ) {
 	stA |= ZeroLengthSegment | VerticalSegment | HorizontalSegment
-	if SamePoints(pt0, pt1) {
+	if t.SamePoints(pt0, pt1) {
 		stA |= OnPoint0Segment | OnPoint1Segment
 	}
 	stB = stA
@@ -157,9 +167,21 @@ func PointLine(
 	pi []Point,
 	stA, stB State,
 ) {
+	return Tolerance{}.PointLine(pt, pb0, pb1)
+}
+
+// PointLine is same as function `PointLine` with tolerance
+func (t Tolerance) PointLine(
+	pt Point,
+	pb0, pb1 Point,
+) (
+	pi []Point,
+	stA, stB State,
+) {
+	eps := t.eps()
 	// Point - Point
-	if SamePoints(pb0, pb1) {
-		return PointPoint(pt, pb0)
+	if t.SamePoints(pb0, pb1) {
+		return t.PointPoint(pt, pb0)
 	}
 	// Point - Line
 
@@ -169,10 +191,10 @@ func PointLine(
 		isTrue   bool
 		tiA, tiB State
 	}{
-		{isTrue: SamePoints(pt, pb0), tiA: OnPoint0Segment | OnPoint1Segment, tiB: OnPoint0Segment},
-		{isTrue: SamePoints(pt, pb1), tiA: OnPoint0Segment | OnPoint1Segment, tiB: OnPoint1Segment},
-		{isTrue: math.Abs(pb0.X-pb1.X) < Eps, tiB: VerticalSegment},
-		{isTrue: math.Abs(pb0.Y-pb1.Y) < Eps, tiB: HorizontalSegment},
+		{isTrue: t.SamePoints(pt, pb0), tiA: OnPoint0Segment | OnPoint1Segment, tiB: OnPoint0Segment},
+		{isTrue: t.SamePoints(pt, pb1), tiA: OnPoint0Segment | OnPoint1Segment, tiB: OnPoint1Segment},
+		{isTrue: math.Abs(pb0.X-pb1.X) < eps, tiB: VerticalSegment},
+		{isTrue: math.Abs(pb0.Y-pb1.Y) < eps, tiB: HorizontalSegment},
 	} {
 		if c.isTrue {
 			stA |= c.tiA
@@ -188,15 +210,15 @@ func PointLine(
 		return
 	}
 
-	if orient := Orientation(pb0, pt, pb1); orient != CollinearPoints {
+	if orient := t.Orientation(pb0, pt, pb1); orient != CollinearPoints {
 		// points is not on line
 		return
 	}
 
 	// is point on line
-	if (math.Min(pb0.X, pb1.X) <= pt.X+Eps && pt.X-Eps <= math.Max(pb0.X, pb1.X) &&
-		math.Min(pb0.Y, pb1.Y) <= pt.Y+Eps && pt.Y-Eps <= math.Max(pb0.Y, pb1.Y)) ||
-		math.Abs(Distance(pb0, pb1)-Distance(pb0, pt)-Distance(pb1, pt)) < Eps {
+	if (math.Min(pb0.X, pb1.X) <= pt.X+eps && pt.X-eps <= math.Max(pb0.X, pb1.X) &&
+		math.Min(pb0.Y, pb1.Y) <= pt.Y+eps && pt.Y-eps <= math.Max(pb0.Y, pb1.Y)) ||
+		math.Abs(Distance(pb0, pb1)-Distance(pb0, pt)-Distance(pb1, pt)) < eps {
 		stA |= OnPoint0Segment | OnPoint1Segment
 		stB |= OnSegment
 		pi = []Point{pt}
@@ -238,16 +260,28 @@ func LineLine(
 	pi []Point,
 	stA, stB State,
 ) {
+	return Tolerance{}.LineLine(pa0, pa1, pb0, pb1)
+}
+
+// LineLine is same as function `LineLine` with tolerance
+func (t Tolerance) LineLine(
+	pa0, pa1 Point,
+	pb0, pb1 Point,
+) (
+	pi []Point,
+	stA, stB State,
+) {
+	eps := t.eps()
 	// Point - Point
-	if SamePoints(pa0, pa1) && SamePoints(pb0, pb1) {
-		return PointPoint(pa0, pb0)
+	if t.SamePoints(pa0, pa1) && t.SamePoints(pb0, pb1) {
+		return t.PointPoint(pa0, pb0)
 	}
 	// Point - Line
-	if SamePoints(pa0, pa1) {
-		return PointLine(pa0, pb0, pb1)
+	if t.SamePoints(pa0, pa1) {
+		return t.PointLine(pa0, pb0, pb1)
 	}
-	if SamePoints(pb0, pb1) {
-		pi, stA, stB = PointLine(pb0, pa0, pa1)
+	if t.SamePoints(pb0, pb1) {
+		pi, stA, stB = t.PointLine(pb0, pa0, pa1)
 		stA, stB = stB, stA
 		return
 	}
@@ -257,14 +291,14 @@ func LineLine(
 		isTrue   bool
 		tiA, tiB State
 	}{
-		{isTrue: SamePoints(pa0, pb0), tiA: OnPoint0Segment, tiB: OnPoint0Segment},
-		{isTrue: SamePoints(pa0, pb1), tiA: OnPoint0Segment, tiB: OnPoint1Segment},
-		{isTrue: SamePoints(pa1, pb0), tiA: OnPoint1Segment, tiB: OnPoint0Segment},
-		{isTrue: SamePoints(pa1, pb1), tiA: OnPoint1Segment, tiB: OnPoint1Segment},
-		{isTrue: math.Abs(pa0.X-pa1.X) < Eps, tiA: VerticalSegment},
-		{isTrue: math.Abs(pa0.Y-pa1.Y) < Eps, tiA: HorizontalSegment},
-		{isTrue: math.Abs(pb0.X-pb1.X) < Eps, tiB: VerticalSegment},
-		{isTrue: math.Abs(pb0.Y-pb1.Y) < Eps, tiB: HorizontalSegment},
+		{isTrue: t.SamePoints(pa0, pb0), tiA: OnPoint0Segment, tiB: OnPoint0Segment},
+		{isTrue: t.SamePoints(pa0, pb1), tiA: OnPoint0Segment, tiB: OnPoint1Segment},
+		{isTrue: t.SamePoints(pa1, pb0), tiA: OnPoint1Segment, tiB: OnPoint0Segment},
+		{isTrue: t.SamePoints(pa1, pb1), tiA: OnPoint1Segment, tiB: OnPoint1Segment},
+		{isTrue: math.Abs(pa0.X-pa1.X) < eps, tiA: VerticalSegment},
+		{isTrue: math.Abs(pa0.Y-pa1.Y) < eps, tiA: HorizontalSegment},
+		{isTrue: math.Abs(pb0.X-pb1.X) < eps, tiB: VerticalSegment},
+		{isTrue: math.Abs(pb0.Y-pb1.Y) < eps, tiB: HorizontalSegment},
 	} {
 		if c.isTrue {
 			stA |= c.tiA
@@ -273,15 +307,15 @@ func LineLine(
 	}
 
 	// collinear lines
-	if Orientation(pa0, pa1, pb0) == CollinearPoints &&
-		Orientation(pa0, pa1, pb1) == CollinearPoints {
+	if t.Orientation(pa0, pa1, pb0) == CollinearPoints &&
+		t.Orientation(pa0, pa1, pb1) == CollinearPoints {
 		stA |= Collinear
 		stB |= Collinear
 		return
 	}
 	// parallel lines
 	// if math.Abs((pa1.Y-pa0.Y)*(pb1.X-pb0.X)-(pb1.Y-pb0.Y)*(pa1.X-pa0.X)) < Eps {
-	if math.Abs(math.FMA(pa1.Y-pa0.Y, pb1.X-pb0.X, -(pb1.Y-pb0.Y)*(pa1.X-pa0.X))) < Eps {
+	if math.Abs(math.FMA(pa1.Y-pa0.Y, pb1.X-pb0.X, -(pb1.Y-pb0.Y)*(pa1.X-pa0.X))) < eps {
 		stA |= Parallel
 		stB |= Parallel
 		return
@@ -290,9 +324,12 @@ func LineLine(
 	// intersection point
 	Aa, Ba, Ca := Line(pa0, pa1)
 	Ab, Bb, Cb := Line(pb0, pb1)
-	x, y, err := Linear(Aa, Ba, -Ca, Ab, Bb, -Cb)
+	x, y, err := t.Linear(Aa, Ba, -Ca, Ab, Bb, -Cb)
 	if err != nil {
-		panic(err)
+		// lines without solution are parallel
+		stA |= Parallel
+		stB |= Parallel
+		return
 	}
 	// only for orthogonal cases
 	if pa0.X == pa1.X {
@@ -309,8 +346,8 @@ func LineLine(
 	}
 	root := Point{X: x, Y: y}
 	{
-		_, _, stBa := PointLine(root, pa0, pa1)
-		_, _, stBb := PointLine(root, pb0, pb1)
+		_, _, stBa := t.PointLine(root, pa0, pa1)
+		_, _, stBb := t.PointLine(root, pb0, pb1)
 		if stBa.Has(OnSegment) &&
 			(stBb.Has(OnSegment) || stBb.Has(OnPoint0Segment) || stBb.Has(OnPoint1Segment)) {
 			stA |= OnSegment
@@ -328,10 +365,10 @@ func LineLine(
 		isTrue   bool
 		tiA, tiB State
 	}{
-		{isTrue: SamePoints(pa0, root), tiA: OnPoint0Segment},
-		{isTrue: SamePoints(pa1, root), tiA: OnPoint1Segment},
-		{isTrue: SamePoints(pb0, root), tiB: OnPoint0Segment},
-		{isTrue: SamePoints(pb1, root), tiB: OnPoint1Segment},
+		{isTrue: t.SamePoints(pa0, root), tiA: OnPoint0Segment},
+		{isTrue: t.SamePoints(pa1, root), tiA: OnPoint1Segment},
+		{isTrue: t.SamePoints(pb0, root), tiB: OnPoint0Segment},
+		{isTrue: t.SamePoints(pb1, root), tiB: OnPoint1Segment},
 	} {
 		if c.isTrue {
 			stA |= c.tiA
@@ -519,7 +556,15 @@ func MirrorPoint(mp0, mp1 Point, sp ...Point) (
 	mp []Point,
 	err error,
 ) {
-	if SamePoints(mp0, mp1) {
+	return Tolerance{}.MirrorPoint(mp0, mp1, sp...)
+}
+
+// MirrorPoint is same as function `MirrorPoint` with tolerance
+func (t Tolerance) MirrorPoint(mp0, mp1 Point, sp ...Point) (
+	mp []Point,
+	err error,
+) {
+	if t.SamePoints(mp0, mp1) {
 		err = fmt.Errorf("MirrorPoint: mirror line is point")
 		return
 	}
@@ -548,6 +593,12 @@ const (
 )
 
 func Orientation(p1, p2, p3 Point) OrientationPoints {
+	return Tolerance{}.Orientation(p1, p2, p3)
+}
+
+// Orientation is same as function `Orientation` with tolerance
+func (t Tolerance) Orientation(p1, p2, p3 Point) OrientationPoints {
+	eps := t.eps()
 	// middle point with collinear points
 	if mid := MiddlePoint(p1, p2); p3.X == mid.X && p3.Y == mid.Y {
 		return CollinearPoints
@@ -573,11 +624,11 @@ func Orientation(p1, p2, p3 Point) OrientationPoints {
 	// algoritm FMA
 	v := math.FMA(p2.Y-p1.Y, p3.X-p2.X, -(p2.X-p1.X)*(p3.Y-p2.Y))
 
-	if math.Abs(v) < 100*Eps {
-		return Orientation128(p1, p2, p3)
+	if math.Abs(v) < 100*eps {
+		return t.Orientation128(p1, p2, p3)
 	}
 	switch {
-	case math.Abs(v) < Eps:
+	case math.Abs(v) < eps:
 		return CollinearPoints
 	case 0 < v:
 		return ClockwisePoints
@@ -597,6 +648,12 @@ var o128 = &sync.Pool{
 }
 
 func Orientation128(p1, p2, p3 Point) OrientationPoints {
+	return Tolerance{}.Orientation128(p1, p2, p3)
+}
+
+// Orientation128 is same as function `Orientation128` with tolerance
+func (t Tolerance) Orientation128(p1, p2, p3 Point) OrientationPoints {
+	eps := t.eps()
 	// 	const prec = 128
 	//
 	// 	var (
@@ -648,7 +705,7 @@ func Orientation128(p1, p2, p3 Point) OrientationPoints {
 	v, _ := (*arr[12]).Float64()
 
 	switch {
-	case math.Abs(v) < Eps:
+	case math.Abs(v) < eps:
 		return CollinearPoints
 	case 0 < v:
 		return ClockwisePoints
@@ -661,26 +718,35 @@ func PointArc(pt Point, Arc0, Arc1, Arc2 Point) (
 	pi []Point,
 	stA, stB State,
 ) {
+	return Tolerance{}.PointArc(pt, Arc0, Arc1, Arc2)
+}
+
+// PointArc is same as function `PointArc` with tolerance
+func (t Tolerance) PointArc(pt Point, Arc0, Arc1, Arc2 Point) (
+	pi []Point,
+	stA, stB State,
+) {
+	eps := t.eps()
 	// Point - Point
-	if SamePoints(Arc0, Arc1) && SamePoints(Arc1, Arc2) {
-		pi, stA, stB = PointPoint(pt, Arc0)
+	if t.SamePoints(Arc0, Arc1) && t.SamePoints(Arc1, Arc2) {
+		pi, stA, stB = t.PointPoint(pt, Arc0)
 		stB |= ArcIsPoint
 		return
 	}
 	// Point - Line
 	{
-		if Orientation(Arc0, Arc1, Arc2) == CollinearPoints {
-			pi, stA, stB = PointLine(pt, Arc0, Arc2)
+		if t.Orientation(Arc0, Arc1, Arc2) == CollinearPoints {
+			pi, stA, stB = t.PointLine(pt, Arc0, Arc2)
 			stB |= ArcIsLine
 			return
 		}
-		if SamePoints(Arc0, Arc1) {
-			pi, stA, stB = PointLine(pt, Arc0, Arc2)
+		if t.SamePoints(Arc0, Arc1) {
+			pi, stA, stB = t.PointLine(pt, Arc0, Arc2)
 			stB |= ArcIsLine
 			return
 		}
-		if SamePoints(Arc1, Arc2) {
-			pi, stA, stB = PointLine(pt, Arc0, Arc2)
+		if t.SamePoints(Arc1, Arc2) {
+			pi, stA, stB = t.PointLine(pt, Arc0, Arc2)
 			stB |= ArcIsLine
 			return
 		}
@@ -689,17 +755,23 @@ func PointArc(pt Point, Arc0, Arc1, Arc2 Point) (
 
 	stA |= ZeroLengthSegment | VerticalSegment | HorizontalSegment
 
-	xc, yc, r := Arc(Arc0, Arc1, Arc2)
+	xc, yc, r, err := t.Arc(Arc0, Arc1, Arc2)
+	if err != nil {
+		// arc without circle is line
+		pi, stA, stB = t.PointLine(pt, Arc0, Arc2)
+		stB |= ArcIsLine
+		return
+	}
 	radius := Distance(Point{X: xc, Y: yc}, pt)
-	if radius < r-Eps || r+Eps < radius {
+	if radius < r-eps || r+eps < radius {
 		// point is outside of arc
 		return
 	}
 	// point is on arc corner ?
-	if SamePoints(pt, Arc0) {
+	if t.SamePoints(pt, Arc0) {
 		stB |= OnPoint0Segment
 	}
-	if SamePoints(pt, Arc2) {
+	if t.SamePoints(pt, Arc2) {
 		stB |= OnPoint1Segment
 	}
 
@@ -707,7 +779,7 @@ func PointArc(pt Point, Arc0, Arc1, Arc2 Point) (
 	if stB.Has(OnPoint0Segment) || stB.Has(OnPoint1Segment) {
 		return
 	}
-	if AngleBetween(Point{X: xc, Y: yc}, Arc0, Arc1, Arc2, pt) {
+	if in, err := t.AngleBetween(Point{X: xc, Y: yc}, Arc0, Arc1, Arc2, pt); err == nil && in {
 		stB |= OnSegment
 	}
 
@@ -719,35 +791,44 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 	pi []Point,
 	stA, stB State,
 ) {
+	return Tolerance{}.LineArc(Line0, Line1, Arc0, Arc1, Arc2)
+}
+
+// LineArc is same as function `LineArc` with tolerance
+func (t Tolerance) LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
+	pi []Point,
+	stA, stB State,
+) {
+	eps := t.eps()
 	// Point - Arc
-	if SamePoints(Line0, Line1) {
-		return PointArc(Line0, Arc0, Arc1, Arc2)
+	if t.SamePoints(Line0, Line1) {
+		return t.PointArc(Line0, Arc0, Arc1, Arc2)
 	}
 	// Line - Point
-	if SamePoints(Arc0, Arc1) && SamePoints(Arc1, Arc2) {
-		pi, stA, stB = PointLine(Arc0, Line0, Line1)
+	if t.SamePoints(Arc0, Arc1) && t.SamePoints(Arc1, Arc2) {
+		pi, stA, stB = t.PointLine(Arc0, Line0, Line1)
 		stA, stB = stB, stA
 		stB |= ArcIsPoint
 		return
 	}
 	// Line - Line
-	if SamePoints(Arc0, Arc1) {
-		pi, stA, stB = LineLine(Line0, Line1, Arc0, Arc2)
+	if t.SamePoints(Arc0, Arc1) {
+		pi, stA, stB = t.LineLine(Line0, Line1, Arc0, Arc2)
 		stB |= ArcIsLine
 		return
 	}
-	if SamePoints(Arc1, Arc2) {
-		pi, stA, stB = LineLine(Line0, Line1, Arc0, Arc2)
+	if t.SamePoints(Arc1, Arc2) {
+		pi, stA, stB = t.LineLine(Line0, Line1, Arc0, Arc2)
 		stB |= ArcIsLine
 		return
 	}
 	{
 		A01, B01, C01 := Line(Arc0, Arc1)
 		A12, B12, C12 := Line(Arc1, Arc2)
-		if math.Abs(A01-A12) < Eps &&
-			math.Abs(B01-B12) < Eps &&
-			math.Abs(C01-C12) < Eps {
-			pi, stA, stB = LineLine(Line0, Line1, Arc0, Arc2)
+		if math.Abs(A01-A12) < eps &&
+			math.Abs(B01-B12) < eps &&
+			math.Abs(C01-C12) < eps {
+			pi, stA, stB = t.LineLine(Line0, Line1, Arc0, Arc2)
 			stB |= ArcIsLine
 			return
 		}
@@ -758,12 +839,12 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 		isTrue   bool
 		tiA, tiB State
 	}{
-		{isTrue: math.Abs(Line0.X-Line1.X) < Eps, tiA: VerticalSegment},
-		{isTrue: math.Abs(Line0.Y-Line1.Y) < Eps, tiA: HorizontalSegment},
-		{isTrue: SamePoints(Line0, Arc0), tiA: OnPoint0Segment, tiB: OnPoint0Segment},
-		{isTrue: SamePoints(Line0, Arc2), tiA: OnPoint0Segment, tiB: OnPoint1Segment},
-		{isTrue: SamePoints(Line1, Arc0), tiA: OnPoint1Segment, tiB: OnPoint0Segment},
-		{isTrue: SamePoints(Line1, Arc2), tiA: OnPoint1Segment, tiB: OnPoint1Segment},
+		{isTrue: math.Abs(Line0.X-Line1.X) < eps, tiA: VerticalSegment},
+		{isTrue: math.Abs(Line0.Y-Line1.Y) < eps, tiA: HorizontalSegment},
+		{isTrue: t.SamePoints(Line0, Arc0), tiA: OnPoint0Segment, tiB: OnPoint0Segment},
+		{isTrue: t.SamePoints(Line0, Arc2), tiA: OnPoint0Segment, tiB: OnPoint1Segment},
+		{isTrue: t.SamePoints(Line1, Arc0), tiA: OnPoint1Segment, tiB: OnPoint0Segment},
+		{isTrue: t.SamePoints(Line1, Arc2), tiA: OnPoint1Segment, tiB: OnPoint1Segment},
 	} {
 		if c.isTrue {
 			stA |= c.tiA
@@ -794,7 +875,13 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 	//	xc = (b1 - a12*yc)*1/a11
 	//	a21*(b1-a12*yc)*1/a11 + a22*yc = b2
 	//	yc*(a22-a21/a11*a12) = b2 - a21/a11*b1
-	xc, yc, r := Arc(Arc0, Arc1, Arc2)
+	xc, yc, r, err := t.Arc(Arc0, Arc1, Arc2)
+	if err != nil {
+		// arc without circle is line
+		pi, stA, stB = t.LineLine(Line0, Line1, Arc0, Arc2)
+		stB |= ArcIsLine
+		return
+	}
 
 	// line may be horizontal, vertical, other
 	A, B, C := Line(Line0, Line1)
@@ -817,9 +904,9 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 		//	x = +/- sqrt(r^2 - (-C/B-yc)^2) + xc
 		D := pow.E2(r) - pow.E2(-C/B-yc)
 		switch {
-		case D < -Eps:
+		case D < -eps:
 			// no intersection
-		case D < Eps:
+		case D < eps:
 			// D == 0
 			// have one root
 			roots = append(roots, Point{X: +xc, Y: Line0.Y})
@@ -844,9 +931,9 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 		//	y = +/- sqrt(r^2 - (-C/A-xc)^2) - yc
 		D := pow.E2(r) - pow.E2(-C/A-xc)
 		switch {
-		case D < -Eps:
+		case D < -eps:
 			// no intersection
-		case D < Eps:
+		case D < eps:
 			// D == 0
 			// have one root
 			roots = append(roots, Point{X: Line0.X, Y: +yc})
@@ -881,9 +968,9 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 		// A and B of line parameters is not zero, so
 		// value a is not a zero and more then zero.
 		switch {
-		case D < -Eps:
+		case D < -eps:
 			// no intersection
-		case D < Eps:
+		case D < eps:
 			// D == 0
 			// have one root
 			y := -b / (2.0 * a)
@@ -908,8 +995,8 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 	}
 
 	for _, root := range roots {
-		_, _, stBa := PointLine(root, Line0, Line1)
-		_, _, stBb := PointArc(root, Arc0, Arc1, Arc2)
+		_, _, stBa := t.PointLine(root, Line0, Line1)
+		_, _, stBb := t.PointArc(root, Arc0, Arc1, Arc2)
 
 		added := false
 
@@ -936,22 +1023,22 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 			tiA, tiB State
 		}{
 			{
-				isTrue: SamePoints(Line0, root) &&
+				isTrue: t.SamePoints(Line0, root) &&
 					(stBa.Has(OnSegment) || stBa.Has(OnPoint0Segment) || stBa.Has(OnPoint1Segment)),
 				tiA: OnPoint0Segment,
 			},
 			{
-				isTrue: SamePoints(Line1, root) &&
+				isTrue: t.SamePoints(Line1, root) &&
 					(stBa.Has(OnSegment) || stBa.Has(OnPoint0Segment) || stBa.Has(OnPoint1Segment)),
 				tiA: OnPoint1Segment,
 			},
 			{
-				isTrue: SamePoints(Arc0, root) &&
+				isTrue: t.SamePoints(Arc0, root) &&
 					(stBb.Has(OnSegment) || stBb.Has(OnPoint0Segment) || stBb.Has(OnPoint1Segment)),
 				tiB: OnPoint0Segment,
 			},
 			{
-				isTrue: SamePoints(Arc2, root) &&
+				isTrue: t.SamePoints(Arc2, root) &&
 					(stBb.Has(OnSegment) || stBb.Has(OnPoint0Segment) || stBb.Has(OnPoint1Segment)),
 				tiB: OnPoint1Segment,
 			},
@@ -971,15 +1058,23 @@ func LineArc(Line0, Line1 Point, Arc0, Arc1, Arc2 Point) (
 //
 //	DO NOT CHECKED POINT ON ARC
 func ArcSplitByPoint(Arc0, Arc1, Arc2 Point, pi ...Point) (res [][3]Point, err error) {
-	switch Orientation(Arc0, Arc1, Arc2) {
+	return Tolerance{}.ArcSplitByPoint(Arc0, Arc1, Arc2, pi...)
+}
+
+// ArcSplitByPoint is same as function `ArcSplitByPoint` with tolerance
+func (t Tolerance) ArcSplitByPoint(Arc0, Arc1, Arc2 Point, pi ...Point) (res [][3]Point, err error) {
+	eps := t.eps()
+	switch t.Orientation(Arc0, Arc1, Arc2) {
 	case CollinearPoints:
 		et := eTree.New("ArcSplitByPoint: collinear")
+		_ = et.Add(ErrNotValidArc)
 		_ = et.Add(fmt.Errorf("arc0 = %.12e", Arc0))
 		_ = et.Add(fmt.Errorf("arc1 = %.12e", Arc1))
 		_ = et.Add(fmt.Errorf("arc2 = %.12e", Arc2))
-		panic(et)
+		err = errorTree{et}
+		return
 	case ClockwisePoints:
-		res, err = ArcSplitByPoint(Arc2, Arc1, Arc0, pi...)
+		res, err = t.ArcSplitByPoint(Arc2, Arc1, Arc0, pi...)
 		if err != nil {
 			return
 		}
@@ -996,12 +1091,12 @@ func ArcSplitByPoint(Arc0, Arc1, Arc2 Point, pi ...Point) (res [][3]Point, err e
 	for _, c := range [...]struct {
 		isTrue bool
 	}{
-		{isTrue: SamePoints(Arc0, Arc1)},
-		{isTrue: SamePoints(Arc1, Arc2)},
-		{isTrue: SamePoints(Arc0, Arc2)},
+		{isTrue: t.SamePoints(Arc0, Arc1)},
+		{isTrue: t.SamePoints(Arc1, Arc2)},
+		{isTrue: t.SamePoints(Arc0, Arc2)},
 	} {
 		if c.isTrue {
-			err = fmt.Errorf("invalid points of arc")
+			err = fmt.Errorf("invalid points of arc: %w", ErrNotValidArc)
 			return
 		}
 	}
@@ -1012,8 +1107,8 @@ againRemove:
 		for _, c := range [...]struct {
 			isTrue bool
 		}{
-			{isTrue: SamePoints(Arc0, p)},
-			{isTrue: SamePoints(Arc2, p)},
+			{isTrue: t.SamePoints(Arc0, p)},
+			{isTrue: t.SamePoints(Arc2, p)},
 		} {
 			if c.isTrue {
 				pi = append(pi[:i], pi[i+1:]...)
@@ -1021,7 +1116,7 @@ againRemove:
 			}
 		}
 		for j := range pi {
-			if i < j && SamePoints(pi[i], pi[j]) {
+			if i < j && t.SamePoints(pi[i], pi[j]) {
 				pi = append(pi[:i], pi[i+1:]...)
 				goto againRemove
 			}
@@ -1029,7 +1124,10 @@ againRemove:
 	}
 
 	// parameter of arc
-	xc, yc, r := Arc(Arc0, Arc1, Arc2)
+	xc, yc, r, err := t.Arc(Arc0, Arc1, Arc2)
+	if err != nil {
+		return
+	}
 
 	// angle for rotate
 	angle0 := math.Atan2(Arc0.Y-yc, Arc0.X-xc)
@@ -1056,7 +1154,7 @@ againRemove:
 	// remove same angles
 again:
 	for i := 1; i < len(b); i++ {
-		if math.Abs(b[i]-b[i-1]) < Eps {
+		if math.Abs(b[i]-b[i-1]) < eps {
 			b = append(b[:i-1], b[i:]...)
 			goto again
 		}
@@ -1128,6 +1226,15 @@ func Linear(
 	a11, a12, b1 float64,
 	a21, a22, b2 float64,
 ) (x, y float64, err error) {
+	return Tolerance{}.Linear(a11, a12, b1, a21, a22, b2)
+}
+
+// Linear is same as function `Linear` with tolerance
+func (t Tolerance) Linear(
+	a11, a12, b1 float64,
+	a21, a22, b2 float64,
+) (x, y float64, err error) {
+	eps := t.eps()
 	// only for debugging
 	// defer func() {
 	// 	if err != nil {
@@ -1141,8 +1248,8 @@ func Linear(
 	// 		err = fmt.Errorf("%v\n%v", err, et)
 	// 	}
 	// }()
-	if math.Abs(a11) < Eps {
-		if math.Abs(a12) < Eps {
+	if math.Abs(a11) < eps {
+		if math.Abs(a12) < eps {
 			err = ErrorNotValidSystem
 			return
 		}
@@ -1160,7 +1267,7 @@ func Linear(
 
 	// algoritm for FMA
 	div := math.FMA(a22, a11, -a21*a12)
-	if math.Abs(div) < Eps {
+	if math.Abs(div) < eps {
 		// only for debugging
 		// err = fmt.Errorf("error div = %e", div)
 		err = ErrorDivZero
@@ -1199,19 +1306,40 @@ func Linear(
 	// return
 }
 
-// Arc return parameters of circle
-func Arc(Arc0, Arc1, Arc2 Point) (xc, yc, r float64) {
-	if SamePoints(Arc0, Arc1) {
-		panic("arc points 0,1 are same")
+// Arc return parameters of circle.
+// For same or collinear points error `ErrNotValidArc` is returned.
+func Arc(Arc0, Arc1, Arc2 Point) (xc, yc, r float64, err error) {
+	return Tolerance{}.Arc(Arc0, Arc1, Arc2)
+}
+
+// Arc is same as function `Arc` with tolerance
+func (t Tolerance) Arc(Arc0, Arc1, Arc2 Point) (xc, yc, r float64, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("Arc")
+			_ = et.Add(ErrNotValidArc)
+			_ = et.Add(fmt.Errorf("arc0 = %.12e", Arc0))
+			_ = et.Add(fmt.Errorf("arc1 = %.12e", Arc1))
+			_ = et.Add(fmt.Errorf("arc2 = %.12e", Arc2))
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if t.SamePoints(Arc0, Arc1) {
+		err = fmt.Errorf("arc points 0,1 are same")
+		return
 	}
-	if SamePoints(Arc1, Arc2) {
-		panic("arc points 1,2 are same")
+	if t.SamePoints(Arc1, Arc2) {
+		err = fmt.Errorf("arc points 1,2 are same")
+		return
 	}
-	if SamePoints(Arc0, Arc2) {
-		panic("arc points 0,2 are same")
+	if t.SamePoints(Arc0, Arc2) {
+		err = fmt.Errorf("arc points 0,2 are same")
+		return
 	}
-	if Orientation(Arc0, Arc1, Arc2) == CollinearPoints {
-		panic(fmt.Errorf("arc on one line: %.12e %.12e %.12e", Arc0, Arc1, Arc2))
+	if t.Orientation(Arc0, Arc1, Arc2) == CollinearPoints {
+		err = fmt.Errorf("arc on one line")
+		return
 	}
 	var (
 		x1, x2, x3 = Arc0.X, Arc1.X, Arc2.X
@@ -1225,8 +1353,7 @@ func Arc(Arc0, Arc1, Arc2 Point) (xc, yc, r float64) {
 	// b1 = math.FMA(x1, x1, -pow.E2(x2)) + math.FMA(y1, y1, -pow.E2(y2))
 	// b2 = math.FMA(x1, x1, -pow.E2(x3)) + math.FMA(y1, y1, -pow.E2(y3))
 	)
-	var err error
-	xc, yc, err = Linear(a11, a12, b1, a21, a22, b2)
+	xc, yc, err = t.Linear(a11, a12, b1, a21, a22, b2)
 	if err == nil {
 		//	(xi-xc)^2+(yi-yc)^2 = R^2
 		r1 := math.Hypot(x1-xc, y1-yc)
@@ -1237,24 +1364,31 @@ func Arc(Arc0, Arc1, Arc2 Point) (xc, yc, r float64) {
 	}
 	// alternative algorithm
 
-	err = errors.Join(err, fmt.Errorf("Arc: %v %v %v", Arc0, Arc1, Arc2))
 	if Log {
-		log.Printf("%v", err)
+		log.Printf("Arc: %v %v %v: %v", Arc0, Arc1, Arc2, err)
 	}
-	panic(err)
+	return
 }
 
-// AngleBetween return true for angle case from <= a <= to
-func AngleBetween(center, from, mid, to, a Point) (res bool) {
-	switch Orientation(from, mid, to) {
+// AngleBetween return true for angle case from <= a <= to.
+// For collinear points of arc error `ErrNotValidArc` is returned.
+func AngleBetween(center, from, mid, to, a Point) (res bool, err error) {
+	return Tolerance{}.AngleBetween(center, from, mid, to, a)
+}
+
+// AngleBetween is same as function `AngleBetween` with tolerance
+func (t Tolerance) AngleBetween(center, from, mid, to, a Point) (res bool, err error) {
+	switch t.Orientation(from, mid, to) {
 	case CollinearPoints:
 		et := eTree.New("AngleBetween: collinear")
+		_ = et.Add(ErrNotValidArc)
 		_ = et.Add(fmt.Errorf("from = %.12e", from))
 		_ = et.Add(fmt.Errorf("mid  = %.12e", mid))
 		_ = et.Add(fmt.Errorf("to   = %.12e", to))
-		panic(et)
+		err = errorTree{et}
+		return
 	case ClockwisePoints:
-		return AngleBetween(center, to, mid, from, a)
+		return t.AngleBetween(center, to, mid, from, a)
 	}
 	// CounterClockwisePoints
 
@@ -1282,10 +1416,10 @@ func AngleBetween(center, from, mid, to, a Point) (res bool) {
 	}
 
 	if b[0] < b[3] && b[3] < b[2] {
-		return true
+		return true, nil
 	}
 
-	return false
+	return false, nil
 }
 
 // Area return area of triangle
@@ -1310,14 +1444,26 @@ func TriangleSplitByPoint(
 	res [][3]Point,
 	lineIntersect int,
 	err error,
+) {
+	return Tolerance{}.TriangleSplitByPoint(pt, tr0, tr1, tr2)
+}
+
+// TriangleSplitByPoint is same as function `TriangleSplitByPoint` with tolerance
+func (t Tolerance) TriangleSplitByPoint(
+	pt Point,
+	tr0, tr1, tr2 Point,
+) (
+	res [][3]Point,
+	lineIntersect int,
+	err error,
 ) {
 	// check valid triangle
 	for is, c := range [...]struct {
 		isTrue bool
 	}{
-		{isTrue: SamePoints(tr0, tr1)},
-		{isTrue: SamePoints(tr1, tr2)},
-		{isTrue: SamePoints(tr0, tr2)},
+		{isTrue: t.SamePoints(tr0, tr1)},
+		{isTrue: t.SamePoints(tr1, tr2)},
+		{isTrue: t.SamePoints(tr0, tr2)},
 	} {
 		if c.isTrue {
 			err = fmt.Errorf("invalid points of triangle: %v", is)
@@ -1334,9 +1480,9 @@ func TriangleSplitByPoint(
 	for _, c := range [...]struct {
 		isTrue bool
 	}{
-		{isTrue: SamePoints(tr0, pt)},
-		{isTrue: SamePoints(tr1, pt)},
-		{isTrue: SamePoints(tr2, pt)},
+		{isTrue: t.SamePoints(tr0, pt)},
+		{isTrue: t.SamePoints(tr1, pt)},
+		{isTrue: t.SamePoints(tr2, pt)},
 	} {
 		if c.isTrue {
 			// point on corner
@@ -1381,13 +1527,13 @@ func TriangleSplitByPoint(
 			state: 2,
 		},
 	} {
-		_, _, stBl := PointLine(pt, line.Line[0], line.Line[1])
+		_, _, stBl := t.PointLine(pt, line.Line[0], line.Line[1])
 		if !stBl.Has(OnSegment) {
 			// point is outside side
 			continue
 		}
 		// point on side
-		switch Orientation(tr0, tr1, tr2) {
+		switch t.Orientation(tr0, tr1, tr2) {
 		case ClockwisePoints:
 			res = [][3]Point{
 				{line.Line[0], pt, line.Free},
@@ -1407,9 +1553,9 @@ func TriangleSplitByPoint(
 
 	// point in body ?
 	orient := [3]OrientationPoints{
-		Orientation(tr0, pt, tr1),
-		Orientation(tr1, pt, tr2),
-		Orientation(tr2, pt, tr0),
+		t.Orientation(tr0, pt, tr1),
+		t.Orientation(tr1, pt, tr2),
+		t.Orientation(tr2, pt, tr0),
 	}
 	if orient[0] != orient[1] ||
 		orient[1] != orient[2] ||
@@ -1445,6 +1591,12 @@ func BorderPoints2d(ps ...Point) (min, max Point) {
 // PointInCircle return true only if point inside circle based
 // on 3 circles points
 func PointInCircle(point Point, circle [3]Point) bool {
+	return Tolerance{}.PointInCircle(point, circle)
+}
+
+// PointInCircle is same as function `PointInCircle` with tolerance
+func (t Tolerance) PointInCircle(point Point, circle [3]Point) bool {
+	eps := t.eps()
 	{
 		// by Wiki
 		// https://ru.wikipedia.org/wiki/%D0%9E%D0%BF%D0%B8%D1%81%D0%B0%D0%BD%D0%BD%D0%B0%D1%8F_%D0%BE%D0%BA%D1%80%D1%83%D0%B6%D0%BD%D0%BE%D1%81%D1%82%D1%8C
@@ -1477,12 +1629,22 @@ func PointInCircle(point Point, circle [3]Point) bool {
 	// check by arc
 	// Problem : for long triangle - possible triangle, but
 	// not possible for arc
-	xc, yc, r := Arc(circle[0], circle[1], circle[2])
-	return Distance(Point{xc, yc}, point)+Eps < r
+	xc, yc, r, err := t.Arc(circle[0], circle[1], circle[2])
+	if err != nil {
+		// circle of collinear points is not exist
+		return false
+	}
+	return Distance(Point{xc, yc}, point)+eps < r
 }
 
 // ConvexHull return chain of convex points
 func ConvexHull(points []Point, withoutCollinearPoints bool) (chain []int, res []Point) {
+	return Tolerance{}.ConvexHull(points, withoutCollinearPoints)
+}
+
+// ConvexHull is same as function `ConvexHull` with tolerance
+func (t Tolerance) ConvexHull(points []Point, withoutCollinearPoints bool) (chain []int, res []Point) {
+	eps := t.eps()
 	if len(points) < 3 {
 		// points slice is small
 		return
@@ -1503,7 +1665,7 @@ func ConvexHull(points []Point, withoutCollinearPoints bool) (chain []int, res [
 			if j == len(indexes) {
 				continue
 			}
-			if math.Abs(points[indexes[j]].Y-points[indexes[i]].Y) < Eps/10 &&
+			if math.Abs(points[indexes[j]].Y-points[indexes[i]].Y) < eps/10 &&
 				points[indexes[j]].X < points[indexes[i]].X {
 				indexes[i], indexes[j] = indexes[j], indexes[i]
 				change = true
@@ -1519,12 +1681,12 @@ func ConvexHull(points []Point, withoutCollinearPoints bool) (chain []int, res [
 	for _, ind := range indexes {
 		point := points[ind]
 		if withoutCollinearPoints {
-			for 2 <= len(hull) && (Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == CollinearPoints ||
-				Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints) {
+			for 2 <= len(hull) && (t.Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == CollinearPoints ||
+				t.Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints) {
 				hull = hull[:len(hull)-1]
 			}
 		} else {
-			for 2 <= len(hull) && Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints {
+			for 2 <= len(hull) && t.Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints {
 				hull = hull[:len(hull)-1]
 			}
 		}
@@ -1537,22 +1699,22 @@ func ConvexHull(points []Point, withoutCollinearPoints bool) (chain []int, res [
 		ind := indexes[i]
 		point := points[ind]
 		if withoutCollinearPoints {
-			for 2 <= len(hull) && (Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == CollinearPoints ||
-				Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints) {
+			for 2 <= len(hull) && (t.Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == CollinearPoints ||
+				t.Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints) {
 				hull = hull[:len(hull)-1]
 			}
 		} else {
-			for 2 <= len(hull) && Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints {
+			for 2 <= len(hull) && t.Orientation(points[hull[len(hull)-2]], points[hull[len(hull)-1]], point) == ClockwisePoints {
 				hull = hull[:len(hull)-1]
 			}
 		}
 		hull = append(hull, ind)
 	}
 	// merge hulls
-	if 0 < len(chain) && 0 < len(hull) && Distance(points[chain[len(chain)-1]], points[hull[0]]) < Eps {
+	if 0 < len(chain) && 0 < len(hull) && Distance(points[chain[len(chain)-1]], points[hull[0]]) < eps {
 		hull = hull[1:]
 	}
-	if 0 < len(chain) && 0 < len(hull) && Distance(points[chain[0]], points[hull[len(hull)-1]]) < Eps {
+	if 0 < len(chain) && 0 < len(hull) && Distance(points[chain[0]], points[hull[len(hull)-1]]) < eps {
 		hull = hull[:len(hull)-1]
 	}
 	chain = append(chain, hull...)
@@ -1567,8 +1729,14 @@ func ConvexHull(points []Point, withoutCollinearPoints bool) (chain []int, res [
 // SamePoints return true only if point on very distance or
 // with same coordinates
 func SamePoints(p0, p1 Point) bool {
+	return Tolerance{}.SamePoints(p0, p1)
+}
+
+// SamePoints is same as function `SamePoints` with tolerance
+func (t Tolerance) SamePoints(p0, p1 Point) bool {
+	eps := t.eps()
 	if p0.X == p1.X && p0.Y == p1.Y {
 		return true
 	}
-	return Distance(p0, p1) < Eps
+	return Distance(p0, p1) < eps
 }
@@ -6,7 +6,8 @@ import (
 	"github.com/Konstantin8105/pow"
 )
 
-// Eps3D is default epsilon for 3D operations
+// Eps3D is default epsilon for 3D operations.
+// For specific precision see `Tolerance`.
 const Eps3D = 1e-5
 
 // Space 3D
@@ -35,15 +36,21 @@ func Distance3d(p0, p1 Point3d) float64 {
 // SamePoints3d return true only if point on very distance or
 // with same coordinates
 func SamePoints3d(p0, p1 Point3d) bool {
+	return Tolerance{}.SamePoints3d(p0, p1)
+}
+
+// SamePoints3d is same as function `SamePoints3d` with tolerance
+func (t Tolerance) SamePoints3d(p0, p1 Point3d) bool {
+	eps3D := t.eps3D()
 	if p0[0] == p1[0] && p0[1] == p1[1] && p0[2] == p1[2] {
 		return true
 	}
 	for i := 0; i < 3; i++ {
-		if Eps3D < math.Abs(p0[i]-p1[i]) {
+		if eps3D < math.Abs(p0[i]-p1[i]) {
 			return false
 		}
 	}
-	return Distance3d(p0, p1) < Eps3D
+	return Distance3d(p0, p1) < eps3D
 }
 
 // PointPoint3d return true only if points have same coordinate
@@ -53,12 +60,24 @@ func PointPoint3d(
 ) (
 	intersect bool,
 ) {
+	return Tolerance{}.PointPoint3d(p0, p1)
+}
+
+// PointPoint3d is same as function `PointPoint3d` with tolerance
+func (t Tolerance) PointPoint3d(
+	p0 Point3d,
+	p1 Point3d,
+) (
+	intersect bool,
+) {
+	eps := t.eps()
+	eps3D := t.eps3D()
 	for i := range p0 {
-		if Eps < math.Abs(p0[i]-p1[i]) {
+		if eps < math.Abs(p0[i]-p1[i]) {
 			return false
 		}
 	}
-	return Distance3d(p0, p1) < Eps3D
+	return Distance3d(p0, p1) < eps3D
 }
 
 // BorderPoints3d return (min..max) points coordinates
@@ -100,18 +119,29 @@ func PointLine3d(
 ) (
 	intersect bool,
 ) {
+	return Tolerance{}.PointLine3d(p, l0, l1)
+}
+
+// PointLine3d is same as function `PointLine3d` with tolerance
+func (t Tolerance) PointLine3d(
+	p Point3d,
+	l0, l1 Point3d,
+) (
+	intersect bool,
+) {
+	eps3D := t.eps3D()
 	// is point on point line
 	for _, v := range [2]*Point3d{&l0, &l1} {
-		if PointPoint3d(p, *v) {
+		if t.PointPoint3d(p, *v) {
 			return
 		}
 	}
 	// line zero lenght
-	if ZeroLine3d(l0, l1) {
+	if t.ZeroLine3d(l0, l1) {
 		return
 	}
 	// compare distances
-	if Eps3D < math.Abs(Distance3d(l0, p)+Distance3d(l1, p)-Distance3d(l0, l1)) {
+	if eps3D < math.Abs(Distance3d(l0, p)+Distance3d(l1, p)-Distance3d(l0, l1)) {
 		return
 	}
 	// is point on line
@@ -124,7 +154,17 @@ func ZeroLine3d(
 ) (
 	zero bool,
 ) {
-	return Distance3d(l0, l1) < Eps3D
+	return Tolerance{}.ZeroLine3d(l0, l1)
+}
+
+// ZeroLine3d is same as function `ZeroLine3d` with tolerance
+func (t Tolerance) ZeroLine3d(
+	l0, l1 Point3d,
+) (
+	zero bool,
+) {
+	eps3D := t.eps3D()
+	return Distance3d(l0, l1) < eps3D
 }
 
 // PointLineRatio3d return point in accroding to line ratio
@@ -148,6 +188,17 @@ func IsParallelLine3d(
 ) (
 	parallel bool,
 ) {
+	return Tolerance{}.IsParallelLine3d(a0, a1, b0, b1)
+}
+
+// IsParallelLine3d is same as function `IsParallelLine3d` with tolerance
+func (t Tolerance) IsParallelLine3d(
+	a0, a1 Point3d,
+	b0, b1 Point3d,
+) (
+	parallel bool,
+) {
+	eps3D := t.eps3D()
 	var (
 		dx1 = a0[0] - a1[0]
 		dy1 = a0[1] - a1[1]
@@ -157,13 +208,13 @@ func IsParallelLine3d(
 		dy2 = b0[1] - b1[1]
 		dz2 = b0[2] - b1[2]
 	)
-	if Eps3D*math.Abs(dy1*dx2) < math.Abs(dy1*dx2-dy2*dx1) {
+	if eps3D*math.Abs(dy1*dx2) < math.Abs(dy1*dx2-dy2*dx1) {
 		return false
 	}
-	if Eps3D*math.Abs(dz1*dx2) < math.Abs(dz1*dx2-dz2*dx1) {
+	if eps3D*math.Abs(dz1*dx2) < math.Abs(dz1*dx2-dz2*dx1) {
 		return false
 	}
-	if Eps3D*math.Abs(dz1*dy2) < math.Abs(dz1*dy2-dz2*dy1) {
+	if eps3D*math.Abs(dz1*dy2) < math.Abs(dz1*dy2-dz2*dy1) {
 		return false
 	}
 	return true
@@ -178,6 +229,18 @@ func LineLine3d(
 	ratioA, ratioB float64,
 	intersect bool,
 ) {
+	return Tolerance{}.LineLine3d(a0, a1, b0, b1)
+}
+
+// LineLine3d is same as function `LineLine3d` with tolerance
+func (t Tolerance) LineLine3d(
+	a0, a1 Point3d,
+	b0, b1 Point3d,
+) (
+	ratioA, ratioB float64,
+	intersect bool,
+) {
+	eps3D := t.eps3D()
 	// Lina a:
 	//	x = xa0 + Ka * (xa1-xa0)
 	//	y = ya0 + Ka * (ya1-ya0)
@@ -203,7 +266,7 @@ func LineLine3d(
 	Ka := make([]float64, 0, 3)
 	Kb := make([]float64, 0, 3)
 	for _, v := range [3][2]int{{0, 1}, {1, 2}, {2, 0}} {
-		x, y, err := Linear(
+		x, y, err := t.Linear(
 			sys[v[0]][0], sys[v[0]][1], sys[v[0]][2],
 			sys[v[1]][0], sys[v[1]][1], sys[v[1]][2],
 		)
@@ -221,7 +284,7 @@ func LineLine3d(
 			if i == 0 {
 				continue
 			}
-			if Eps3D < math.Abs(ks[i-1]-ks[i]) {
+			if eps3D < math.Abs(ks[i-1]-ks[i]) {
 				return
 			}
 		}
@@ -289,7 +352,18 @@ func PointOnPlane3d(
 ) (
 	on bool,
 ) {
-	return math.Abs(math.FMA(A, p[0], math.FMA(B, p[1], math.FMA(C, p[2], D)))) < Eps3D
+	return Tolerance{}.PointOnPlane3d(A, B, C, D, p)
+}
+
+// PointOnPlane3d is same as function `PointOnPlane3d` with tolerance
+func (t Tolerance) PointOnPlane3d(
+	A, B, C, D float64,
+	p Point3d,
+) (
+	on bool,
+) {
+	eps3D := t.eps3D()
+	return math.Abs(math.FMA(A, p[0], math.FMA(B, p[1], math.FMA(C, p[2], D)))) < eps3D
 }
 
 // ZeroTriangle3d return true only if triangle have zero area
@@ -298,10 +372,19 @@ func ZeroTriangle3d(
 ) (
 	zero bool,
 ) {
-	return ZeroLine3d(t0, t1) || ZeroLine3d(t1, t2) || ZeroLine3d(t2, t0) ||
-		PointLine3d(t0, t1, t2) ||
-		PointLine3d(t1, t0, t2) ||
-		PointLine3d(t2, t1, t0)
+	return Tolerance{}.ZeroTriangle3d(t0, t1, t2)
+}
+
+// ZeroTriangle3d is same as function `ZeroTriangle3d` with tolerance
+func (t Tolerance) ZeroTriangle3d(
+	t0, t1, t2 Point3d,
+) (
+	zero bool,
+) {
+	return t.ZeroLine3d(t0, t1) || t.ZeroLine3d(t1, t2) || t.ZeroLine3d(t2, t0) ||
+		t.PointLine3d(t0, t1, t2) ||
+		t.PointLine3d(t1, t0, t2) ||
+		t.PointLine3d(t2, t1, t0)
 }
 
 // PointTriangle3d return true only if point located inside triangle but
@@ -311,9 +394,19 @@ func PointTriangle3d(
 	t0, t1, t2 Point3d,
 ) (
 	intersect bool,
+) {
+	return Tolerance{}.PointTriangle3d(p, t0, t1, t2)
+}
+
+// PointTriangle3d is same as function `PointTriangle3d` with tolerance
+func (t Tolerance) PointTriangle3d(
+	p Point3d,
+	t0, t1, t2 Point3d,
+) (
+	intersect bool,
 ) {
 	A, B, C, D := Plane(t0, t1, t2)
-	if !PointOnPlane3d(A, B, C, D, p) {
+	if !t.PointOnPlane3d(A, B, C, D, p) {
 		// point is not plane
 		return
 	}
@@ -324,7 +417,7 @@ func PointTriangle3d(
 		{&t2, &p, &t0, &t1},
 	} {
 		var rA, rB float64
-		rA, rB, intersect = LineLine3d(*v[0], *v[1], *v[2], *v[3])
+		rA, rB, intersect = t.LineLine3d(*v[0], *v[1], *v[2], *v[3])
 		if !intersect || rA < 0.0 || rB <= 0.0 || 1.0 <= rB {
 			// point is not in triangle
 			return false
@@ -343,8 +436,20 @@ func LineTriangle3dI1(
 	intersect bool,
 	pi []Point3d,
 ) {
+	return Tolerance{}.LineTriangle3dI1(l0, l1, t0, t1, t2)
+}
+
+// LineTriangle3dI1 is same as function `LineTriangle3dI1` with tolerance
+func (t Tolerance) LineTriangle3dI1(
+	l0, l1 Point3d,
+	t0, t1, t2 Point3d,
+) (
+	intersect bool,
+	pi []Point3d,
+) {
+	eps3D := t.eps3D()
 	A, B, C, D := Plane(t0, t1, t2)
-	if PointOnPlane3d(A, B, C, D, l0) && PointOnPlane3d(A, B, C, D, l1) {
+	if t.PointOnPlane3d(A, B, C, D, l0) && t.PointOnPlane3d(A, B, C, D, l1) {
 		// Lines points on Plane
 		return
 	}
@@ -352,7 +457,7 @@ func LineTriangle3dI1(
 	// Line intersect Triangle on one point
 	// div := ((l1[0]-l0[0])*A + (l1[1]-l0[1])*B + (l1[2]-l0[2])*C)
 	div := math.FMA(l1[0]-l0[0], A, math.FMA(l1[1]-l0[1], B, (l1[2]-l0[2])*C))
-	if math.Abs(div) < Eps3D {
+	if math.Abs(div) < eps3D {
 		return
 	}
 	// Ka := (A*l0[0] + B*l0[1] + C*l0[2] + D) / (-div)
@@ -364,7 +469,7 @@ func LineTriangle3dI1(
 
 	p := PointLineRatio3d(l0, l1, Ka)
 
-	if !PointTriangle3d(p, t0, t1, t2) {
+	if !t.PointTriangle3d(p, t0, t1, t2) {
 		return
 	}
 	intersect = true
@@ -383,22 +488,33 @@ func LineTriangle3dI2(
 ) (
 	intersect bool,
 	pi []Point3d,
+) {
+	return Tolerance{}.LineTriangle3dI2(l0, l1, t0, t1, t2)
+}
+
+// LineTriangle3dI2 is same as function `LineTriangle3dI2` with tolerance
+func (t Tolerance) LineTriangle3dI2(
+	l0, l1 Point3d,
+	t0, t1, t2 Point3d,
+) (
+	intersect bool,
+	pi []Point3d,
 ) {
 	A, B, C, D := Plane(t0, t1, t2)
-	if !(PointOnPlane3d(A, B, C, D, l0) && PointOnPlane3d(A, B, C, D, l1)) {
+	if !(t.PointOnPlane3d(A, B, C, D, l0) && t.PointOnPlane3d(A, B, C, D, l1)) {
 		// line not on triangle plane
 		return
 	}
 	// intersection line inside triangle
 	for _, v := range [2]*Point3d{&l0, &l1} {
-		if PointTriangle3d(*v, t0, t1, t2) {
+		if t.PointTriangle3d(*v, t0, t1, t2) {
 			intersect = true
 			pi = append(pi, *v)
 		}
 	}
 	// line outside triangle
 	for _, v := range [3][2]*Point3d{{&t0, &t1}, {&t1, &t2}, {&t2, &t0}} {
-		if rA, rB, ill := LineLine3d(l0, l1, *v[0], *v[1]); ill &&
+		if rA, rB, ill := t.LineLine3d(l0, l1, *v[0], *v[1]); ill &&
 			0 < rA && rA < 1 && 0 < rB && rB < 1 {
 			intersect = true
 			p := PointLineRatio3d(l0, l1, rA)
@@ -439,12 +555,18 @@ func TriangleTriangle3d(
 
 // Mirror3d return mirror points by mirror plane
 func Mirror3d(plane [3]Point3d, points ...Point3d) (mir []Point3d) {
+	return Tolerance{}.Mirror3d(plane, points...)
+}
+
+// Mirror3d is same as function `Mirror3d` with tolerance
+func (t Tolerance) Mirror3d(plane [3]Point3d, points ...Point3d) (mir []Point3d) {
+	eps3D := t.eps3D()
 	// plane equation `A*x+B*y+C*z+D=0`
 	A, B, C, D := Plane(plane[0], plane[1], plane[2])
 
 	// A * A + B * B + C * C
 	div := math.FMA(A, A, math.FMA(B, B, C*C))
-	if div < Eps3D {
+	if div < eps3D {
 		return
 	}
 
@@ -2,6 +2,7 @@ package gog
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -1364,7 +1365,10 @@ func TestAngleBetween(t *testing.T) {
 
 	for i := range tcs {
 		t.Run(tcs[i].name, func(t *testing.T) {
-			res := AngleBetween(Point{tcs[i].xc, tcs[i].yc}, tcs[i].from, tcs[i].mid, tcs[i].to, tcs[i].a)
+			res, err := AngleBetween(Point{tcs[i].xc, tcs[i].yc}, tcs[i].from, tcs[i].mid, tcs[i].to, tcs[i].a)
+			if err != nil {
+				t.Fatal(err)
+			}
 			if res != tcs[i].expect {
 				t.Errorf("not valid: %v", tcs[i])
 			}
@@ -1373,6 +1377,27 @@ func TestAngleBetween(t *testing.T) {
 
 }
 
+func TestArcCollinear(t *testing.T) {
+	ps := [3]Point{{0, 0}, {1, 0}, {2, 0}}
+	if _, _, _, err := Arc(ps[0], ps[1], ps[2]); !errors.Is(err, ErrNotValidArc) {
+		t.Errorf("Arc: not valid error: %v", err)
+	}
+	if _, _, _, err := Arc(ps[0], ps[0], ps[2]); !errors.Is(err, ErrNotValidArc) {
+		t.Errorf("Arc: not valid error: %v", err)
+	}
+	if _, err := AngleBetween(Point{1, 1}, ps[0], ps[1], ps[2], Point{1, 2}); !errors.Is(err, ErrNotValidArc) {
+		t.Errorf("AngleBetween: not valid error: %v", err)
+	}
+	if _, err := ArcSplitByPoint(ps[0], ps[1], ps[2]); !errors.Is(err, ErrNotValidArc) {
+		t.Errorf("ArcSplitByPoint: not valid error: %v", err)
+	}
+	// arc is line
+	pi, _, stB := LineArc(Point{1, -1}, Point{1, 1}, ps[0], ps[1], ps[2])
+	if !stB.Has(ArcIsLine) || len(pi) != 1 || !SamePoints(pi[0], ps[1]) {
+		t.Errorf("LineArc: not valid intersection: %v %s", pi, stB)
+	}
+}
+
 func TestArcSplitByPoint(t *testing.T) {
 	tcs := [][]Point{
 		{ // 0
@@ -0,0 +1,481 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// HealOptions is options of model repair by `Model.Heal`
+type HealOptions struct {
+	// Gap is maximal distance for snapping of free ends of lines and arcs
+	// and maximal distance between middle point of arc and chord for
+	// converting arc to line.
+	// If value is zero, then 1000 epsilon of model tolerance is used.
+	Gap float64
+}
+
+// ChangeKind is kind of model change
+type ChangeKind string
+
+// Kinds of model changes by `Model.Heal`
+const (
+	RemoveInvalid    ChangeKind = "remove invalid element"
+	SnapPoints       ChangeKind = "snap points"
+	SnapPointToLine  ChangeKind = "snap point to line"
+	RemoveZeroLength ChangeKind = "remove zero length line"
+	RemoveDegenerate ChangeKind = "remove degenerate element"
+	RemoveDuplicate  ChangeKind = "remove duplicate element"
+	ArcToLine        ChangeKind = "convert arc to line"
+	MergeLines       ChangeKind = "merge overlapping lines"
+)
+
+// Change is change of model with indexes of elements in model before
+// repair and coordinates of points before and after change
+type Change struct {
+	Kind      ChangeKind `json:"kind"`
+	Points    []int      `json:"points,omitempty"`
+	Lines     []int      `json:"lines,omitempty"`
+	Arcs      []int      `json:"arcs,omitempty"`
+	Triangles []int      `json:"triangles,omitempty"`
+	Quadrs    []int      `json:"quadrs,omitempty"`
+	Coords    []Point    `json:"coords,omitempty"`
+}
+
+// String return description of change
+func (c Change) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s:", c.Kind)
+	for _, e := range []struct {
+		name    string
+		indexes []int
+	}{
+		{"lines", c.Lines},
+		{"arcs", c.Arcs},
+		{"triangles", c.Triangles},
+		{"quadrs", c.Quadrs},
+		{"points", c.Points},
+	} {
+		if 0 < len(e.indexes) {
+			fmt.Fprintf(&sb, " %s %v", e.name, e.indexes)
+		}
+	}
+	if 0 < len(c.Coords) {
+		fmt.Fprintf(&sb, " coords %.9e", c.Coords)
+	}
+	return sb.String()
+}
+
+// Heal repair model before intersection and triangulation and return
+// list of all changes. Steps of repair:
+//
+//   - remove elements with not valid indexes of points;
+//   - snap free end of line or arc to nearest point closer gap;
+//   - snap free end of line to nearest line closer gap;
+//   - remove zero length lines and degenerate elements;
+//   - convert nearly collinear arcs to lines;
+//   - remove duplicate elements;
+//   - merge collinear overlapping lines with same tag.
+//
+// Indexes of changes are indexes of elements in model before repair.
+func (m *Model) Heal(opts HealOptions) (changes []Change) {
+	tol := m.tolerance()
+	gap := opts.Gap
+	if gap <= 0 {
+		gap = 1000 * tol.eps()
+	}
+	add := func(c Change) {
+		changes = append(changes, c)
+	}
+	// valid return true for valid indexes of element points
+	valid := func(ids []int) bool {
+		for _, id := range ids {
+			if id < 0 || len(m.Points) <= id {
+				return false
+			}
+		}
+		return true
+	}
+	// sorted indexes of points as key of element
+	key := func(ids ...int) string {
+		s := append([]int(nil), ids...)
+		sort.Ints(s)
+		return fmt.Sprint(s)
+	}
+	// elements of model with indexes of points after snapping
+	type element struct {
+		index int   // index of element in model before repair
+		arc   bool  // line is converted arc
+		ps    []int // indexes of points
+		tag   int
+	}
+	// change of lines with indexes of lines and converted arcs
+	lineChange := func(kind ChangeKind, els ...element) Change {
+		c := Change{Kind: kind}
+		for _, e := range els {
+			if e.arc {
+				c.Arcs = append(c.Arcs, e.index)
+			} else {
+				c.Lines = append(c.Lines, e.index)
+			}
+		}
+		sort.Ints(c.Lines)
+		sort.Ints(c.Arcs)
+		return c
+	}
+	var lines, arcs, triangles, quadrs []element
+	for _, e := range []struct {
+		list *[]element
+		size int
+		get  func(i int) ([]int, int)
+		c    func(i int) Change
+	}{
+		{&lines, len(m.Lines), func(i int) ([]int, int) {
+			return m.Lines[i][:2], m.Lines[i][2]
+		}, func(i int) Change {
+			return Change{Kind: RemoveInvalid, Lines: []int{i}}
+		}},
+		{&arcs, len(m.Arcs), func(i int) ([]int, int) {
+			return m.Arcs[i][:3], m.Arcs[i][3]
+		}, func(i int) Change {
+			return Change{Kind: RemoveInvalid, Arcs: []int{i}}
+		}},
+		{&triangles, len(m.Triangles), func(i int) ([]int, int) {
+			return m.Triangles[i][:3], m.Triangles[i][3]
+		}, func(i int) Change {
+			return Change{Kind: RemoveInvalid, Triangles: []int{i}}
+		}},
+		{&quadrs, len(m.Quadrs), func(i int) ([]int, int) {
+			return m.Quadrs[i][:4], m.Quadrs[i][4]
+		}, func(i int) Change {
+			return Change{Kind: RemoveInvalid, Quadrs: []int{i}}
+		}},
+	} {
+		for i := 0; i < e.size; i++ {
+			ps, tag := e.get(i)
+			if !valid(ps) {
+				add(e.c(i))
+				continue
+			}
+			*e.list = append(*e.list, element{
+				index: i,
+				ps:    append([]int(nil), ps...),
+				tag:   tag,
+			})
+		}
+	}
+
+	// snap free ends of lines and arcs to nearest points
+	points := append([]Point(nil), m.Points...)
+	root := make([]int, len(points)) // index of snapped point
+	for i := range root {
+		root[i] = i
+	}
+	{
+		amount := make([]int, len(points)) // amount of lines and arcs in point
+		own := make([][]int, len(points))  // points of line or arc in point
+		for _, list := range [][]element{lines, arcs} {
+			for _, e := range list {
+				for _, p := range []int{e.ps[0], e.ps[len(e.ps)-1]} {
+					amount[p]++
+					own[p] = e.ps
+				}
+			}
+		}
+		boxes := make([]box, len(points))
+		for i, p := range points {
+			boxes[i] = boxOf(p)
+		}
+		tree := newRtree(boxes)
+		// free end is snapped only to point, which is not snapped
+		// and is not free end with bigger index, so chains of near
+		// points are not collapsed
+		for p := range points {
+			if amount[p] != 1 {
+				continue
+			}
+			best, dist := -1, math.Inf(1)
+			near := boxOf(
+				Point{X: points[p].X - gap, Y: points[p].Y - gap},
+				Point{X: points[p].X + gap, Y: points[p].Y + gap},
+			)
+			for _, q := range tree.search(near) {
+				if root[q] != q || (amount[q] == 1 && p < q) || slices.Contains(own[p], q) {
+					continue
+				}
+				if d := Distance(points[p], points[q]); d <= gap && d < dist {
+					best, dist = q, d
+				}
+			}
+			if best < 0 {
+				continue
+			}
+			root[p] = best
+			add(Change{
+				Kind:   SnapPoints,
+				Points: []int{p, best},
+				Coords: []Point{points[p], points[best]},
+			})
+		}
+		for _, list := range [][]element{lines, arcs, triangles, quadrs} {
+			for _, e := range list {
+				for k := range e.ps {
+					e.ps[k] = root[e.ps[k]]
+				}
+			}
+		}
+	}
+	// snap free end of line to nearest line
+	{
+		amount := make([]int, len(points)) // amount of lines and arcs in point
+		for _, e := range lines {
+			amount[e.ps[0]]++
+			amount[e.ps[1]]++
+		}
+		for _, e := range arcs {
+			amount[e.ps[0]]++
+			amount[e.ps[2]]++
+		}
+		boxes := make([]box, len(lines))
+		for k, e := range lines {
+			boxes[k] = boxOf(points[e.ps[0]], points[e.ps[1]])
+			boxes[k].min.X -= gap
+			boxes[k].min.Y -= gap
+			boxes[k].max.X += gap
+			boxes[k].max.Y += gap
+		}
+		tree := newRtree(boxes)
+		for p := range points {
+			if amount[p] != 1 {
+				continue
+			}
+			best, dist := -1, math.Inf(1)
+			for _, k := range tree.search(boxOf(points[p])) {
+				e := lines[k]
+				if e.ps[0] == p || e.ps[1] == p {
+					continue
+				}
+				if d := segmentPointDistance(points[p], points[e.ps[0]], points[e.ps[1]]); d < dist {
+					best, dist = k, d
+				}
+			}
+			if best < 0 || gap < dist || dist < tol.eps() {
+				continue
+			}
+			var (
+				p0, p1 = points[lines[best].ps[0]], points[lines[best].ps[1]]
+				dx, dy = p1.X - p0.X, p1.Y - p0.Y
+				t      = ((points[p].X-p0.X)*dx + (points[p].Y-p0.Y)*dy) / (dx*dx + dy*dy)
+				snap   = Point{X: p0.X + t*dx, Y: p0.Y + t*dy}
+			)
+			if t <= 0 || 1 <= t {
+				// nearest point is end of line and snapped before
+				continue
+			}
+			c := lineChange(SnapPointToLine, lines[best])
+			c.Points = []int{p}
+			c.Coords = []Point{points[p], snap}
+			add(c)
+			points[p] = snap
+		}
+	}
+	// remove zero length lines and degenerate elements
+	{
+		var list []element
+		for _, e := range lines {
+			if e.ps[0] == e.ps[1] || tol.SamePoints(points[e.ps[0]], points[e.ps[1]]) {
+				add(Change{Kind: RemoveZeroLength, Lines: []int{e.index}})
+				continue
+			}
+			list = append(list, e)
+		}
+		lines = list
+	}
+	{
+		var list []element
+		for _, e := range arcs {
+			p0, p1, p2 := points[e.ps[0]], points[e.ps[1]], points[e.ps[2]]
+			if tol.SamePoints(p0, p2) {
+				add(Change{Kind: RemoveDegenerate, Arcs: []int{e.index}})
+				continue
+			}
+			if tol.SamePoints(p0, p1) || tol.SamePoints(p1, p2) ||
+				segmentPointDistance(p1, p0, p2) <= gap {
+				add(Change{Kind: ArcToLine, Arcs: []int{e.index}})
+				lines = append(lines, element{
+					index: e.index,
+					arc:   true,
+					ps:    []int{e.ps[0], e.ps[2]},
+					tag:   e.tag,
+				})
+				continue
+			}
+			list = append(list, e)
+		}
+		arcs = list
+	}
+	for _, e := range []struct {
+		list *[]element
+		c    func(ids []int) Change
+	}{
+		{&triangles, func(ids []int) Change { return Change{Triangles: ids} }},
+		{&quadrs, func(ids []int) Change { return Change{Quadrs: ids} }},
+	} {
+		var list []element
+		for _, el := range *e.list {
+			degenerate := false
+			for k := range el.ps {
+				var (
+					a = points[el.ps[k]]
+					b = points[el.ps[(k+1)%len(el.ps)]]
+					c = points[el.ps[(k+2)%len(el.ps)]]
+				)
+				if tol.Orientation(a, b, c) == CollinearPoints {
+					degenerate = true
+				}
+			}
+			if degenerate {
+				c := e.c([]int{el.index})
+				c.Kind = RemoveDegenerate
+				add(c)
+				continue
+			}
+			list = append(list, el)
+		}
+		*e.list = list
+	}
+	// remove duplicate elements
+	for _, e := range []struct {
+		list *[]element
+		c    func(els ...element) Change
+	}{
+		{&lines, func(els ...element) Change { return lineChange(RemoveDuplicate, els...) }},
+		{&arcs, func(els ...element) Change {
+			return Change{Kind: RemoveDuplicate, Arcs: []int{els[0].index, els[1].index}}
+		}},
+		{&triangles, func(els ...element) Change {
+			return Change{Kind: RemoveDuplicate, Triangles: []int{els[0].index, els[1].index}}
+		}},
+		{&quadrs, func(els ...element) Change {
+			return Change{Kind: RemoveDuplicate, Quadrs: []int{els[0].index, els[1].index}}
+		}},
+	} {
+		var list []element
+		first := map[string]int{}
+		for _, el := range *e.list {
+			k := key(el.ps...)
+			if i, ok := first[k]; ok {
+				add(e.c(list[i], el))
+				continue
+			}
+			first[k] = len(list)
+			list = append(list, el)
+		}
+		*e.list = list
+	}
+	// merge collinear overlapping lines with same tag
+	{
+		group := make([]int, len(lines))
+		for i := range group {
+			group[i] = i
+		}
+		var findGroup func(i int) int
+		findGroup = func(i int) int {
+			if group[i] != i {
+				group[i] = findGroup(group[i])
+			}
+			return group[i]
+		}
+		boxes := make([]box, len(lines))
+		for k, e := range lines {
+			boxes[k] = boxOf(points[e.ps[0]], points[e.ps[1]])
+		}
+		tree := newRtree(boxes)
+		for i, e := range lines {
+			for _, j := range tree.search(boxes[i]) {
+				if j <= i || lines[j].tag != e.tag {
+					continue
+				}
+				if overlapLines(tol,
+					points[e.ps[0]], points[e.ps[1]],
+					points[lines[j].ps[0]], points[lines[j].ps[1]],
+				) {
+					a, b := findGroup(i), findGroup(j)
+					if a > b {
+						a, b = b, a
+					}
+					group[b] = a
+				}
+			}
+		}
+		members := map[int][]int{}
+		for i := range lines {
+			g := findGroup(i)
+			members[g] = append(members[g], i)
+		}
+		var list []element
+		for i, e := range lines {
+			ms := members[i]
+			if findGroup(i) != i {
+				continue
+			}
+			if len(ms) == 1 {
+				list = append(list, e)
+				continue
+			}
+			// merge lines by extreme points along first line
+			var (
+				p0, p1   = points[e.ps[0]], points[e.ps[1]]
+				dx, dy   = p1.X - p0.X, p1.Y - p0.Y
+				minP     = e.ps[0]
+				maxP     = e.ps[1]
+				min, max = 0.0, dx*dx + dy*dy
+				els      []element
+			)
+			for _, k := range ms {
+				els = append(els, lines[k])
+				for _, p := range lines[k].ps {
+					t := (points[p].X-p0.X)*dx + (points[p].Y-p0.Y)*dy
+					if t < min {
+						min, minP = t, p
+					}
+					if max < t {
+						max, maxP = t, p
+					}
+				}
+			}
+			add(lineChange(MergeLines, els...))
+			list = append(list, element{index: e.index, arc: e.arc, ps: []int{minP, maxP}, tag: e.tag})
+		}
+		lines = list
+	}
+
+	// create repaired model
+	index := make([]int, len(points)) // new indexes of points
+	var ps []Point
+	for i := range points {
+		if root[i] != i {
+			continue
+		}
+		index[i] = len(ps)
+		ps = append(ps, points[i])
+	}
+	m.Points = ps
+	m.resetIndex()
+	m.Lines, m.Arcs, m.Triangles, m.Quadrs = nil, nil, nil, nil
+	for _, e := range lines {
+		m.Lines = append(m.Lines, [3]int{index[e.ps[0]], index[e.ps[1]], e.tag})
+	}
+	for _, e := range arcs {
+		m.Arcs = append(m.Arcs, [4]int{index[e.ps[0]], index[e.ps[1]], index[e.ps[2]], e.tag})
+	}
+	for _, e := range triangles {
+		m.Triangles = append(m.Triangles, [4]int{index[e.ps[0]], index[e.ps[1]], index[e.ps[2]], e.tag})
+	}
+	for _, e := range quadrs {
+		m.Quadrs = append(m.Quadrs, [5]int{index[e.ps[0]], index[e.ps[1]], index[e.ps[2]], index[e.ps[3]], e.tag})
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package gog
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestHeal(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{2, 0}, 1)
+	m.AddLine(Point{2, 0}, Point{2, 2}, 1)
+	m.AddLine(Point{2, 2}, Point{0, 2}, 1)
+	m.AddLine(Point{0, 2}, Point{0, 0}, 1)
+	m.Points = append(m.Points,
+		Point{2 + 1e-9, 2},  // 4: near point 2
+		Point{1, 1},         // 5
+		Point{1, 2 - 1e-10}, // 6: near line 2
+		Point{0.5, 0},       // 7
+		Point{3, 0},         // 8
+		Point{1.8, 0.8},     // 9
+		Point{2.5, 1e-12},   // 10
+		Point{4, 0},         // 11
+	)
+	m.Lines = append(m.Lines,
+		[3]int{4, 5, 2},   // 4: end near point 2
+		[3]int{5, 6, 2},   // 5: end near line 2
+		[3]int{5, 5, 2},   // 6: zero length
+		[3]int{0, 5, 2},   // 7
+		[3]int{5, 0, 2},   // 8: duplicate of 7
+		[3]int{7, 8, 1},   // 9: overlapping with 0
+		[3]int{0, 100, 1}, // 10: not valid
+	)
+	m.Arcs = append(m.Arcs,
+		[4]int{1, 10, 11, 1}, // 0: nearly collinear, overlapping with 9
+		[4]int{5, 9, 1, 2},   // 1
+	)
+	m.Triangles = append(m.Triangles,
+		[4]int{0, 7, 1, 3}, // 0: degenerate
+		[4]int{0, 1, 5, 3}, // 1
+		[4]int{5, 1, 0, 3}, // 2: duplicate of 1
+	)
+
+	changes := m.Heal(HealOptions{Gap: 1e-6})
+	var sb strings.Builder
+	for _, c := range changes {
+		fmt.Fprintf(&sb, "%s\n", c)
+	}
+	fmt.Fprintf(&sb, "%s", m.String())
+	compare.Test(t, filepath.Join("testdata", "Heal"), []byte(sb.String()))
+
+	if r := m.Validate(); !r.Valid() {
+		t.Errorf("not valid model:\n%s", r)
+	}
+	if _, err := New(m); err != nil {
+		t.Error(err)
+	}
+	// repaired model without changes
+	if changes = m.Heal(HealOptions{Gap: 1e-6}); len(changes) != 0 {
+		t.Errorf("changes of repaired model: %v", changes)
+	}
+}
+
+func TestHealSplitLine(t *testing.T) {
+	// finely split polyline with segments shorter gap
+	var m Model
+	for i := 0; i < 10; i++ {
+		m.AddLine(Point{0.004 * float64(i), 0}, Point{0.004 * float64(i+1), 0}, 1)
+	}
+	exp := m.String()
+	if changes := m.Heal(HealOptions{Gap: 0.005}); len(changes) != 0 {
+		t.Errorf("changes of split line: %v", changes)
+	}
+	if act := m.String(); act != exp {
+		t.Errorf("not same model:\n%s\n%s", act, exp)
+	}
+}
+
+func TestHealSnapChain(t *testing.T) {
+	// free ends are snapped to point with minimal index only
+	// and not by chain of near points
+	var m Model
+	m.AddLine(Point{0, 0}, Point{0, 1}, 1)
+	m.AddLine(Point{0.004, 0}, Point{1, -1}, 1)
+	m.AddLine(Point{0.008, 0}, Point{2, -1}, 1)
+	changes := m.Heal(HealOptions{Gap: 0.005})
+	if len(changes) != 1 || changes[0].Kind != SnapPoints ||
+		changes[0].Points[0] != 2 || changes[0].Points[1] != 0 {
+		t.Fatalf("not valid changes: %v", changes)
+	}
+	if len(m.Points) != 5 || len(m.Lines) != 3 {
+		t.Errorf("not valid model:\n%s", m)
+	}
+}
@@ -0,0 +1,127 @@
+package gog
+
+import (
+	"context"
+	"fmt"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// MeshInput is model with seed points of holes and regions for
+// triangulation by `NewFromInput`. Seed point is point inside of region
+// bounded by model lines, see `Region.Interior` of `Model.Regions`.
+type MeshInput struct {
+	Model   Model
+	Holes   []Point      // seed points of holes
+	Regions []RegionSeed // seed points of regions with materials
+}
+
+// RegionSeed is seed point of region with not negative material
+type RegionSeed struct {
+	Point    Point
+	Material int
+}
+
+// NewFromInput return triangulation created by model of input. Triangles
+// of holes are removed and triangles of regions have materials of seed
+// points. Regions without seed points have auto-numbered materials
+// from 50 or from maximal material of seed points plus one.
+func NewFromInput(input MeshInput) (mesh *Mesh, err error) {
+	return newMeshInput(nil, input)
+}
+
+// NewFromInputContext is same as `NewFromInput` with cancellation by
+// context and progress of operation, see `WithProgress`.
+func NewFromInputContext(ctx context.Context, input MeshInput) (mesh *Mesh, err error) {
+	mesh, err = newMeshInput(newProgress(ctx), input)
+	return mesh, contextError(ctx, err)
+}
+
+// newMeshInput return triangulation created by input with progress
+// of operation
+func newMeshInput(p *progress, input MeshInput) (mesh *Mesh, err error) {
+	mesh, err = newMesh(p, input.Model)
+	if err != nil {
+		return
+	}
+	if len(input.Holes) == 0 && len(input.Regions) == 0 {
+		return
+	}
+	defer func() {
+		if err != nil {
+			et := eTree.New("NewFromInput")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if err = mesh.Materials(); err != nil {
+		return
+	}
+	// materials of seed points
+	const hole = Removed
+	materials := map[int]int{} // auto-numbered material to seed material
+	seed := func(p Point, material int) error {
+		mats, err := mesh.GetMaterials(p)
+		if err != nil {
+			return err
+		}
+		// point on edge between triangles of same material
+		for _, m := range mats {
+			if m != mats[0] {
+				return fmt.Errorf("not equal materials %v in point %.9e", mats, p)
+			}
+		}
+		if m, ok := materials[mats[0]]; ok && m != material {
+			return fmt.Errorf("different seed materials %d and %d in point %.9e",
+				m, material, p)
+		}
+		materials[mats[0]] = material
+		return nil
+	}
+	next := 50 // next auto-numbered material
+	for _, r := range input.Regions {
+		if r.Material < 0 {
+			// negative values are reserved for holes and states
+			err = fmt.Errorf("negative material %d of seed point %.9e",
+				r.Material, r.Point)
+			return
+		}
+		if err = seed(r.Point, r.Material); err != nil {
+			return
+		}
+		next = max(next, r.Material+1)
+	}
+	for _, h := range input.Holes {
+		if err = seed(h, hole); err != nil {
+			return
+		}
+	}
+	// regions without seed points
+	for i, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		if _, ok := materials[tr[3]]; !ok {
+			materials[tr[3]] = next
+			next++
+		}
+		if m := materials[tr[3]]; m == hole {
+			mesh.model.Triangles[i][0] = Removed
+		} else {
+			mesh.model.Triangles[i][3] = m
+		}
+	}
+	// sides of holes are boundary
+	for i := range mesh.Triangles {
+		if mesh.model.Triangles[i][0] == Removed {
+			mesh.Triangles[i] = [3]int{Removed, Removed, Removed}
+			continue
+		}
+		for side, n := range mesh.Triangles[i] {
+			if n != Boundary && mesh.model.Triangles[n][0] == Removed {
+				mesh.Triangles[i][side] = Boundary
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,109 @@
+package gog
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewFromInput(t *testing.T) {
+	var m Model
+	square := func(a, b float64) {
+		m.AddLine(Point{a, a}, Point{b, a}, 1)
+		m.AddLine(Point{b, a}, Point{b, b}, 1)
+		m.AddLine(Point{b, b}, Point{a, b}, 1)
+		m.AddLine(Point{a, b}, Point{a, a}, 1)
+	}
+	square(0, 10)
+	square(1, 3) // hole
+	square(4, 6) // region with material 7
+	square(7, 9) // region without seed point
+	input := MeshInput{
+		Model:   m,
+		Holes:   []Point{{2, 2}},
+		Regions: []RegionSeed{{Point{0.5, 0.5}, 3}, {Point{5, 5}, 70}},
+	}
+	mesh, err := NewFromInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Check(); err != nil {
+		t.Fatal(err)
+	}
+	area := map[int]float64{}
+	for _, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		area[tr[3]] += Area(
+			mesh.model.Points[tr[0]],
+			mesh.model.Points[tr[1]],
+			mesh.model.Points[tr[2]],
+		)
+	}
+	expect := map[int]float64{3: 100 - 3*4, 70: 4, 71: 4}
+	if len(area) != len(expect) {
+		t.Fatalf("not valid materials: %v", area)
+	}
+	for mat, a := range expect {
+		if math.Abs(area[mat]-a) > 1e-9 {
+			t.Errorf("material %d: not valid area %v", mat, area[mat])
+		}
+	}
+	// point in hole is outside of mesh
+	if _, err = mesh.AddPoint(Point{2, 2}, Movable); !errors.Is(err, ErrPointOutside) {
+		t.Errorf("point in hole: %v", err)
+	}
+	// materials of regions after refinement
+	if err = mesh.SplitFunc(func(p1, p2 Point) bool {
+		return 1 < Distance(p1, p2)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Check(); err != nil {
+		t.Fatal(err)
+	}
+	for p, mat := range map[Point]int{{0.5, 0.5}: 3, {5, 5}: 70, {8, 8}: 71} {
+		if mats, err := mesh.GetMaterials(p); err != nil || len(mats) == 0 || mats[0] != mat {
+			t.Errorf("point %v after refinement: %v %v", p, mats, err)
+		}
+	}
+
+	// seed points by regions of model
+	var seeds []RegionSeed
+	for i, r := range m.Regions() {
+		seeds = append(seeds, RegionSeed{r.Interior, i + 1})
+	}
+	mesh, err = NewFromInput(MeshInput{Model: m, Regions: seeds})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range seeds {
+		if mats, err := mesh.GetMaterials(s.Point); err != nil ||
+			len(mats) == 0 || mats[0] != s.Material {
+			t.Errorf("seed %v: %v %v", s, mats, err)
+		}
+	}
+
+	// not valid seed points
+	for name, in := range map[string]MeshInput{
+		"outside": {Model: m, Holes: []Point{{20, 20}}},
+		"different materials": {Model: m, Regions: []RegionSeed{
+			{Point{5, 5}, 1}, {Point{5.5, 5.5}, 2},
+		}},
+		"hole and region": {Model: m,
+			Holes:   []Point{{5, 5}},
+			Regions: []RegionSeed{{Point{5.5, 5.5}, 2}},
+		},
+		"removed material":  {Model: m, Regions: []RegionSeed{{Point{5, 5}, Removed}}},
+		"negative material": {Model: m, Regions: []RegionSeed{{Point{5, 5}, -10}}},
+	} {
+		_, err = NewFromInput(in)
+		if err == nil {
+			t.Errorf("%s: error is not found", name)
+		}
+		if name == "outside" && !errors.Is(err, ErrPointOutside) {
+			t.Errorf("%s: not valid error: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package gog
+
+import "math"
+
+// locator is structure for fast location of point in triangulation.
+// Location is remembering walk from start triangle by triangle sides.
+// Start triangle is taken from uniform grid of triangles, grid is
+// rebuilded after doubling of triangles amount.
+type locator struct {
+	last   int     // index of last found triangle
+	size   int     // amount of triangles at building of grid
+	min    Point   // minimal corner of grid
+	step   float64 // size of grid cell
+	nx, ny int     // amount of cells
+	cells  []int   // index of triangle in each cell
+	turn   int     // counter for changing of first checked side
+}
+
+// lineIndex is index of lines by points for fast search of line on side
+// of triangle. New lines are at the end of list and indexed at search,
+// removed lines are ignored at search.
+type lineIndex struct {
+	size  int              // amount of indexed lines
+	lines map[[2]int][]int // indexes of lines by sorted indexes of points
+}
+
+// lineKey return key of line between points with indexes a, b
+func lineKey(a, b int) [2]int {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
+
+// linesBetween return indexes of not removed lines between points with
+// indexes a, b
+func (mesh *Mesh) linesBetween(a, b int) (ids []int) {
+	l := &mesh.lines
+	if l.lines == nil || len(mesh.model.Lines) < l.size {
+		l.size = 0
+		l.lines = map[[2]int][]int{}
+	}
+	for ; l.size < len(mesh.model.Lines); l.size++ {
+		line := mesh.model.Lines[l.size]
+		if line[2] == Removed {
+			continue
+		}
+		k := lineKey(line[0], line[1])
+		l.lines[k] = append(l.lines[k], l.size)
+	}
+	k := lineKey(a, b)
+	for _, i := range l.lines[k] {
+		line := mesh.model.Lines[i]
+		if line[2] == Removed || lineKey(line[0], line[1]) != k {
+			continue
+		}
+		ids = append(ids, i)
+	}
+	return
+}
+
+// fixedLine return true if fixed line between points with indexes a, b
+// is exist
+func (mesh *Mesh) fixedLine(a, b int) bool {
+	for _, i := range mesh.linesBetween(a, b) {
+		if mesh.model.Lines[i][2] == Fixed {
+			return true
+		}
+	}
+	return false
+}
+
+// around return triangles with point of index `p` by rotation around
+// point from triangle `tr` with that point
+func (mesh *Mesh) around(tr, p int) (trs []int) {
+	corner := func(tr int) int {
+		for j, tp := range mesh.model.Triangles[tr][:3] {
+			if tp == p {
+				return j
+			}
+		}
+		return -1
+	}
+	if mesh.removed(tr) || corner(tr) < 0 {
+		return
+	}
+	trs = append(trs, tr)
+	// rotate by sides from point and, if boundary is found, by sides
+	// to point
+	for _, shift := range []int{0, 2} {
+		for next := tr; len(trs) <= len(mesh.model.Triangles); {
+			c := corner(next)
+			if c < 0 {
+				return
+			}
+			next = mesh.Triangles[next][(c+shift)%3]
+			if next == tr {
+				// closed rotation
+				return
+			}
+			if next < 0 {
+				break
+			}
+			trs = append(trs, next)
+		}
+	}
+	return
+}
+
+// triangleWith return index of triangle with point of index `p`.
+// If triangle is not found, then return -1.
+func (mesh *Mesh) triangleWith(p int) int {
+	has := func(tr int) bool {
+		for _, tp := range mesh.model.Triangles[tr][:3] {
+			if tp == p {
+				return true
+			}
+		}
+		return false
+	}
+	if tr := mesh.locate(mesh.model.Points[p]); 0 <= tr && has(tr) {
+		return tr
+	}
+	for tr := range mesh.model.Triangles {
+		if !mesh.removed(tr) && has(tr) {
+			return tr
+		}
+	}
+	return -1
+}
+
+// cell return index of grid cell for point
+func (l *locator) cell(p Point) int {
+	ix := int((p.X - l.min.X) / l.step)
+	iy := int((p.Y - l.min.Y) / l.step)
+	ix = max(0, min(l.nx-1, ix))
+	iy = max(0, min(l.ny-1, iy))
+	return iy*l.nx + ix
+}
+
+// removed return true if triangle is not exist
+func (mesh *Mesh) removed(tr int) bool {
+	return tr < 0 || len(mesh.model.Triangles) <= tr ||
+		mesh.model.Triangles[tr][0] == Removed
+}
+
+// buildGrid of triangles by centers
+func (mesh *Mesh) buildGrid() {
+	l := &mesh.locator
+	l.size = len(mesh.model.Triangles)
+	l.cells = nil
+	if len(mesh.model.Points) == 0 || l.size == 0 {
+		return
+	}
+	min, max := BorderPoints2d(mesh.model.Points...)
+	var (
+		w = max.X - min.X
+		h = max.Y - min.Y
+		// approximately 2 triangles in each cell
+		amount = float64(l.size)/2 + 1
+	)
+	l.min = min
+	l.step = math.Sqrt(w * h / amount)
+	if !(0 < l.step) {
+		l.step = math.Max(w, h) / amount
+	}
+	if !(0 < l.step) {
+		l.step = 1
+	}
+	l.nx = int(w/l.step) + 1
+	l.ny = int(h/l.step) + 1
+	l.cells = make([]int, l.nx*l.ny)
+	for i := range l.cells {
+		l.cells[i] = Removed
+	}
+	for i, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		var (
+			p0 = mesh.model.Points[tr[0]]
+			p1 = mesh.model.Points[tr[1]]
+			p2 = mesh.model.Points[tr[2]]
+		)
+		l.cells[l.cell(Point{
+			X: (p0.X + p1.X + p2.X) / 3.0,
+			Y: (p0.Y + p1.Y + p2.Y) / 3.0,
+		})] = i
+	}
+}
+
+// start return index of triangle for start of walk near to point.
+// If triangles are not exist, then return -1.
+func (mesh *Mesh) start(p Point) int {
+	l := &mesh.locator
+	if l.cells == nil || 2*l.size+16 < len(mesh.model.Triangles) {
+		mesh.buildGrid()
+	}
+	if l.cells != nil {
+		if tr := l.cells[l.cell(p)]; !mesh.removed(tr) {
+			return tr
+		}
+	}
+	if !mesh.removed(l.last) {
+		return l.last
+	}
+	// new triangles are at the end
+	for tr := len(mesh.model.Triangles) - 1; 0 <= tr; tr-- {
+		if !mesh.removed(tr) {
+			return tr
+		}
+	}
+	return -1
+}
+
+// walk from triangle `tr` to triangle with point inside or on side.
+// If walk is not possible, then return -1. For example: point outside
+// of triangulation or removed triangles on path.
+func (mesh *Mesh) walk(tr int, p Point) int {
+	tol := mesh.model.tolerance()
+	l := &mesh.locator
+	from := Undefined
+	for step := 0; step < len(mesh.model.Triangles)+3; step++ {
+		if mesh.removed(tr) {
+			return -1
+		}
+		t := mesh.model.Triangles[tr]
+		next := Undefined
+		l.turn++
+		for k := 0; k < 3; k++ {
+			j := (k + l.turn) % 3
+			n := mesh.Triangles[tr][j]
+			// point is inside of side to triangle before on path
+			if n == from {
+				continue
+			}
+			if tol.Orientation(
+				mesh.model.Points[t[j]],
+				mesh.model.Points[t[(j+1)%3]],
+				p,
+			) == CounterClockwisePoints {
+				next = n
+				break
+			}
+		}
+		if next == Undefined {
+			l.last = tr
+			return tr
+		}
+		if next == Boundary {
+			return -1
+		}
+		from, tr = tr, next
+	}
+	return -1
+}
+
+// locate return index of triangle with point inside or on side.
+// If point is outside of all triangles, then return -1.
+func (mesh *Mesh) locate(p Point) int {
+	tol := mesh.model.tolerance()
+	if tr := mesh.walk(mesh.start(p), p); 0 <= tr {
+		return tr
+	}
+	for i, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		inside := true
+		for j := 0; j < 3; j++ {
+			if tol.Orientation(
+				mesh.model.Points[tr[j]],
+				mesh.model.Points[tr[(j+1)%3]],
+				p,
+			) == CounterClockwisePoints {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			mesh.locator.last = i
+			return i
+		}
+	}
+	return -1
+}
+
+// inBox return true if point is inside of box around points p0, p1
+// with tolerance eps
+func inBox(p, p0, p1 Point, eps float64) bool {
+	return math.Min(p0.X, p1.X)-eps <= p.X && p.X <= math.Max(p0.X, p1.X)+eps &&
+		math.Min(p0.Y, p1.Y)-eps <= p.Y && p.Y <= math.Max(p0.Y, p1.Y)+eps
+}
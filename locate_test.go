@@ -0,0 +1,99 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomModel return model with random points inside square
+func randomModel(size int) (m Model) {
+	r := rand.New(rand.NewSource(0))
+	m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+	m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+	m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+	for i := 0; i < size; i++ {
+		m.AddPoint(Point{X: 0.01 + 0.98*r.Float64(), Y: 0.01 + 0.98*r.Float64()})
+	}
+	return
+}
+
+func TestLocate(t *testing.T) {
+	mesh, err := New(randomModel(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Check(); err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := Point{X: r.Float64(), Y: r.Float64()}
+		tr := mesh.locate(p)
+		if tr < 0 {
+			t.Fatalf("point %v is not found", p)
+		}
+		for j := 0; j < 3; j++ {
+			if Orientation(
+				mesh.model.Points[mesh.model.Triangles[tr][j]],
+				mesh.model.Points[mesh.model.Triangles[tr][(j+1)%3]],
+				p,
+			) == CounterClockwisePoints {
+				t.Fatalf("point %v is outside of triangle %d", p, tr)
+			}
+		}
+	}
+	for _, p := range []Point{{-1, 0.5}, {2, 2}, {0.5, 1.5}} {
+		if tr := mesh.locate(p); tr != -1 {
+			t.Errorf("point %v outside of mesh is found in %d", p, tr)
+		}
+	}
+	// removed triangles on path
+	if err = mesh.RemoveMaterials(Point{0.5, 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	if tr := mesh.locate(Point{0.5, 0.5}); tr != -1 {
+		t.Errorf("point in removed triangles is found in %d", tr)
+	}
+}
+
+func TestAddPointLocated(t *testing.T) {
+	for _, size := range []int{10, 100, 1000} {
+		t.Run(fmt.Sprintf("%d", size), func(t *testing.T) {
+			mesh, err := New(randomModel(size))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = mesh.Check(); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(mesh.model.Points); n != size+4 {
+				t.Errorf("not valid amount of points: %d", n)
+			}
+		})
+	}
+}
+
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkNewRandom/1000         	       1	  20443560 ns/op
+// BenchmarkNewRandom/10000        	       1	 260807962 ns/op
+// BenchmarkNewRandom/100000       	       1	6415663449 ns/op
+func BenchmarkNewRandom(b *testing.B) {
+	for _, size := range []int{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("%d", size), func(b *testing.B) {
+			model := randomModel(size)
+			// fixed lines on boundary
+			if err := model.Split(1.0 / math.Sqrt(float64(size))); err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := New(model); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
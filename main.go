@@ -30,9 +30,13 @@ func main() {
 	m.AddLine(gog.Point{X: -1, Y: 0}, gog.Point{X: 1, Y: 0}, 2)
 	m.AddLine(gog.Point{X: 0, Y: -1}, gog.Point{X: 0, Y: 1}, 3)
 	view() // 0
-	m.Intersection()
+	if err := m.Intersection(); err != nil {
+		panic(err)
+	}
 	view() // 1
-	m.Split(0.2)
+	if err := m.Split(0.2); err != nil {
+		panic(err)
+	}
 	view() // 2
 	m.ArcsToLines()
 	view() // 3
@@ -40,7 +44,9 @@ func main() {
 	view() // 4
 	m.ConvexHullTriangles()
 	view() // 5
-	m.Intersection()
+	if err := m.Intersection(); err != nil {
+		panic(err)
+	}
 	view() // 6
 	m.RemoveEmptyPoints()
 	view() // 7
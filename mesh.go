@@ -0,0 +1,233 @@
+package gog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// MeshVersion is version of mesh schema in JSON format
+const MeshVersion = 1
+
+// meshFile is mesh state in JSON format
+type meshFile struct {
+	Version   int
+	Model     Model    // points, triangles with materials and fixed lines
+	Points    []int    // states of points: Fixed, Movable
+	Triangles [][3]int // indexes of near triangles
+}
+
+// MarshalJSON return full state of mesh in JSON format: points,
+// triangles with materials, near triangles, fixed lines and states of
+// points. Mesh may be restored by `UnmarshalJSON` for continue of work.
+func (mesh Mesh) MarshalJSON() ([]byte, error) {
+	return json.Marshal(meshFile{
+		Version:   MeshVersion,
+		Model:     mesh.model,
+		Points:    mesh.Points,
+		Triangles: mesh.Triangles,
+	})
+}
+
+// UnmarshalJSON restore mesh from JSON format of `MarshalJSON`.
+// Sizes and indexes are checked, for full check use `Mesh.Check`.
+func (mesh *Mesh) UnmarshalJSON(data []byte) (err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("Mesh.UnmarshalJSON")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	var f meshFile
+	if err = json.Unmarshal(data, &f); err != nil {
+		return
+	}
+	if f.Version != MeshVersion {
+		err = fmt.Errorf("not supported version of mesh: %d", f.Version)
+		return
+	}
+	if len(f.Points) != len(f.Model.Points) {
+		err = fmt.Errorf("not same amount of points and states: %d != %d",
+			len(f.Model.Points), len(f.Points))
+		return
+	}
+	if len(f.Triangles) != len(f.Model.Triangles) {
+		err = fmt.Errorf("not same amount of triangles and near triangles: %d != %d",
+			len(f.Model.Triangles), len(f.Triangles))
+		return
+	}
+	var (
+		np = len(f.Model.Points)
+		nt = len(f.Triangles)
+	)
+	for i, tr := range f.Model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		for j := 0; j < 3; j++ {
+			if tr[j] < 0 || np <= tr[j] {
+				err = fmt.Errorf("triangle %d have not valid point %d", i, tr[j])
+				return
+			}
+			if n := f.Triangles[i][j]; n != Boundary && (n < 0 || nt <= n) {
+				err = fmt.Errorf("triangle %d have not valid near triangle %d", i, n)
+				return
+			}
+		}
+	}
+	for i, l := range f.Model.Lines {
+		if l[2] == Removed {
+			continue
+		}
+		for j := 0; j < 2; j++ {
+			if l[j] < 0 || np <= l[j] {
+				err = fmt.Errorf("line %d have not valid point %d", i, l[j])
+				return
+			}
+		}
+	}
+	for i, state := range f.Points {
+		if state != Fixed && state != Movable {
+			err = fmt.Errorf("point %d have not valid state %d", i, state)
+			return
+		}
+	}
+	*mesh = Mesh{
+		model:     f.Model,
+		Points:    f.Points,
+		Triangles: f.Triangles,
+	}
+	return
+}
+
+// NewFromTriangles create mesh from triangulated model. All points of
+// model must be corners of triangles. Lines of model are fixed lines of
+// mesh and must be sides of triangles. Points are Fixed, if points are:
+//
+//   - on boundary of mesh;
+//   - on lines of model;
+//   - on border of materials, it is side between triangles with
+//     different tags, so `Mesh.Smooth` keeps shape of materials.
+//
+// Other points are Movable.
+// Triangles are converted to clockwise orientation and near triangles
+// are calculated. Mesh is checked by `Mesh.Check`.
+func NewFromTriangles(model Model) (mesh *Mesh, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("NewFromTriangles")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if len(model.Arcs) != 0 || len(model.Quadrs) != 0 {
+		err = fmt.Errorf("arcs and quadrs are not supported")
+		return
+	}
+	// spatial hash of points is not shared with input model
+	model.index = nil
+	mesh = new(Mesh)
+	// configuration with absolute tolerance by input model
+	mesh.model.config = model.config
+	mesh.model.config.Tolerance = model.tolerance()
+	mesh.model.Points = make([]Point, len(model.Points))
+	copy(mesh.model.Points, model.Points)
+	mesh.Points = make([]int, len(model.Points))
+	for i := range mesh.Points {
+		mesh.Points[i] = Movable
+	}
+	var (
+		np   = len(model.Points)
+		used = make([]bool, np)
+	)
+	for i, tr := range model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		for j := 0; j < 3; j++ {
+			if tr[j] < 0 || np <= tr[j] {
+				err = fmt.Errorf("triangle %d have not valid point %d", i, tr[j])
+				return
+			}
+			used[tr[j]] = true
+		}
+		switch mesh.orientation(
+			model.Points[tr[0]],
+			model.Points[tr[1]],
+			model.Points[tr[2]],
+		) {
+		case CounterClockwisePoints:
+			tr[1], tr[2] = tr[2], tr[1]
+		case CollinearPoints:
+			err = fmt.Errorf("collinear triangle %d", i)
+			return
+		}
+		mesh.model.Triangles = append(mesh.model.Triangles, tr)
+	}
+	for i := range used {
+		if !used[i] {
+			err = fmt.Errorf("point %d is not corner of triangles", i)
+			return
+		}
+	}
+	// near triangles by sides
+	type side struct {
+		tr, index int
+	}
+	sides := map[[2]int][]side{}
+	key := func(a, b int) [2]int {
+		if b < a {
+			a, b = b, a
+		}
+		return [2]int{a, b}
+	}
+	mesh.Triangles = make([][3]int, len(mesh.model.Triangles))
+	for i, tr := range mesh.model.Triangles {
+		for j := 0; j < 3; j++ {
+			k := key(tr[j], tr[(j+1)%3])
+			sides[k] = append(sides[k], side{tr: i, index: j})
+			mesh.Triangles[i][j] = Boundary
+		}
+	}
+	for k, ss := range sides {
+		switch len(ss) {
+		case 1:
+			// boundary
+			mesh.Points[k[0]] = Fixed
+			mesh.Points[k[1]] = Fixed
+		case 2:
+			mesh.Triangles[ss[0].tr][ss[0].index] = ss[1].tr
+			mesh.Triangles[ss[1].tr][ss[1].index] = ss[0].tr
+			if mesh.model.Triangles[ss[0].tr][3] != mesh.model.Triangles[ss[1].tr][3] {
+				// border of materials
+				mesh.Points[k[0]] = Fixed
+				mesh.Points[k[1]] = Fixed
+			}
+		default:
+			err = fmt.Errorf("side between points %d and %d have %d triangles",
+				k[0], k[1], len(ss))
+			return
+		}
+	}
+	// fixed lines
+	for i, l := range model.Lines {
+		if l[2] == Removed {
+			continue
+		}
+		if l[0] < 0 || np <= l[0] || l[1] < 0 || np <= l[1] {
+			err = fmt.Errorf("line %d have not valid points", i)
+			return
+		}
+		if _, ok := sides[key(l[0], l[1])]; !ok {
+			err = fmt.Errorf("line %d is not side of triangles", i)
+			return
+		}
+		mesh.model.Lines = append(mesh.model.Lines, [3]int{l[0], l[1], Fixed})
+		mesh.Points[l[0]] = Fixed
+		mesh.Points[l[1]] = Fixed
+	}
+	err = mesh.Check()
+	return
+}
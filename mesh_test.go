@@ -0,0 +1,199 @@
+package gog
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// squareMesh return mesh of square with splitted triangles
+func squareMesh(t *testing.T) *Mesh {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+	m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+	m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+	m.AddLine(Point{0.2, 0.5}, Point{0.8, 0.5}, 2)
+	mesh, err := New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Split(0.3); err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Materials(); err != nil {
+		t.Fatal(err)
+	}
+	return mesh
+}
+
+func TestMeshJSON(t *testing.T) {
+	mesh := squareMesh(t)
+	b, err := json.Marshal(mesh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Mesh
+	if err = json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Check(); err != nil {
+		t.Fatal(err)
+	}
+	// spatial hash of points is ignored
+	mesh.model.index = nil
+	if !reflect.DeepEqual(r.model, mesh.model) ||
+		!reflect.DeepEqual(r.Points, mesh.Points) ||
+		!reflect.DeepEqual(r.Triangles, mesh.Triangles) {
+		t.Fatalf("not same meshes")
+	}
+	// continue work with restored mesh
+	for _, m := range []*Mesh{mesh, &r} {
+		if err = m.Split(0.1); err != nil {
+			t.Fatal(err)
+		}
+		if err = m.Smooth(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var m1, m2 Model
+	m1.Get(mesh)
+	m2.Get(&r)
+	if m1.String() != m2.String() {
+		t.Errorf("not same results")
+	}
+}
+
+func TestMeshUnmarshalJSONError(t *testing.T) {
+	for i, str := range []string{
+		`{`,
+		`{"Version":2}`,
+		`{"Version":1,"Model":{"Points":[[0,0]]},"Points":[]}`,
+		`{"Version":1,"Model":{"Triangles":[[0,1,2,1]]},"Triangles":[]}`,
+		`{"Version":1,"Model":{"Points":[[0,0]],"Triangles":[[0,1,2,1]]},` +
+			`"Points":[100],"Triangles":[[-1,-1,-1]]}`,
+		`{"Version":1,"Model":{"Points":[[0,0],[1,0],[0,1]],"Triangles":[[0,1,2,1]]},` +
+			`"Points":[100,100,100],"Triangles":[[-1,5,-1]]}`,
+		`{"Version":1,"Model":{"Points":[[0,0]],"Lines":[[0,1,100]]},"Points":[100]}`,
+		`{"Version":1,"Model":{"Points":[[0,0]]},"Points":[1]}`,
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var mesh Mesh
+			if err := json.Unmarshal([]byte(str), &mesh); err == nil {
+				t.Fatalf("error is not found")
+			} else {
+				t.Logf("%v", err)
+			}
+		})
+	}
+}
+
+func TestNewFromTriangles(t *testing.T) {
+	mesh := squareMesh(t)
+	var m Model
+	m.Get(mesh)
+	// counterclockwise triangles
+	for i := range m.Triangles {
+		if i%2 == 0 {
+			m.Triangles[i][1], m.Triangles[i][2] = m.Triangles[i][2], m.Triangles[i][1]
+		}
+	}
+	m.Lines = append(m.Lines, [3]int{m.Triangles[0][0], m.Triangles[0][1], 5})
+
+	r, err := NewFromTriangles(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	materials, err := r.GetMaterials(Point{0.5, 0.25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(materials) != 1 || materials[0] != 50 {
+		t.Errorf("not valid materials: %v", materials)
+	}
+	if err = r.Split(0.1); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Check(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewFromTrianglesSmooth(t *testing.T) {
+	// square with not optimal interior point
+	m := Model{
+		Points: []Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.2, 0.3}},
+		Triangles: [][4]int{
+			{0, 1, 4, 1}, {1, 2, 4, 1}, {2, 3, 4, 1}, {3, 0, 4, 1},
+		},
+		Lines: [][3]int{{0, 1, 1}},
+	}
+	mesh, err := NewFromTriangles(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, state := range []int{Fixed, Fixed, Fixed, Fixed, Movable} {
+		if mesh.Points[i] != state {
+			t.Errorf("point %d: not valid state %d", i, mesh.Points[i])
+		}
+	}
+	if err = mesh.Smooth(); err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Check(); err != nil {
+		t.Fatal(err)
+	}
+	if p := mesh.model.Points[4]; Distance(p, Point{0.2, 0.3}) < 0.1 {
+		t.Errorf("interior point is not moved: %v", p)
+	}
+	for i, p := range m.Points[:4] {
+		if mesh.model.Points[i] != p {
+			t.Errorf("boundary point %d is moved: %v", i, mesh.model.Points[i])
+		}
+	}
+
+	// interior point on border of materials
+	m.Triangles[2][3] = 2
+	m.Triangles[3][3] = 2
+	if mesh, err = NewFromTriangles(m); err != nil {
+		t.Fatal(err)
+	}
+	if mesh.Points[4] != Fixed {
+		t.Errorf("point on border of materials is not fixed")
+	}
+}
+
+func TestNewFromTrianglesError(t *testing.T) {
+	var (
+		ps = []Point{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {-1, -1}, {2, 2}}
+	)
+	for i, m := range []Model{
+		{Points: ps[:3], Arcs: [][4]int{{0, 1, 2, 1}}},
+		{Points: ps[:3], Triangles: [][4]int{{0, 1, 5, 1}}},
+		{Points: []Point{{0, 0}, {1, 1}, {2, 2}}, Triangles: [][4]int{{0, 1, 2, 1}}},
+		{Points: ps[:4], Triangles: [][4]int{{0, 1, 2, 1}}},
+		{
+			Points:    ps[:6],
+			Triangles: [][4]int{{0, 1, 2, 1}, {1, 2, 3, 1}, {1, 2, 4, 1}, {1, 2, 5, 1}},
+		},
+		{
+			Points:    ps[:4],
+			Triangles: [][4]int{{0, 1, 2, 1}, {1, 3, 2, 1}},
+			Lines:     [][3]int{{0, 3, 1}},
+		},
+		{
+			Points:    ps[:4],
+			Triangles: [][4]int{{0, 1, 2, 1}, {1, 3, 2, 1}},
+			Lines:     [][3]int{{0, 7, 1}},
+		},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if _, err := NewFromTriangles(m); err == nil {
+				t.Fatalf("error is not found")
+			} else {
+				t.Logf("%v", err)
+			}
+		})
+	}
+}
@@ -10,13 +10,19 @@ import (
 	"sort"
 )
 
-// Model of points, lines, arcs for prepare of triangulation
+// Model of points, lines, arcs for prepare of triangulation.
+// Spatial hash of points is rebuilded, if slice of points is replaced
+// or shortened. After direct change of coordinates of points replace
+// slice of points by copy, for example `m.Points = slices.Clone(m.Points)`.
 type Model struct {
 	Points    []Point  // Points is slice of points
 	Lines     [][3]int // Lines store 2 index of Points and last for tag
 	Arcs      [][4]int // Arcs store 3 index of Points and last for tag
 	Triangles [][4]int // Triangles store 3 index of Points and last for tag/material
 	Quadrs    [][5]int // Rectanges store 4 index of Points and last for tag/material
+
+	index  *pointIndex // spatial hash of points only for AddPoint
+	config Config      // configuration of operations
 }
 
 // TagProperty return length of lines, area of triangles for each tag.
@@ -67,13 +73,20 @@ func (src Model) Copy() (dst Model) {
 	// Triangles
 	dst.Triangles = make([][4]int, len(src.Triangles))
 	copy(dst.Triangles, src.Triangles)
+	// Quadrs
+	dst.Quadrs = make([][5]int, len(src.Quadrs))
+	copy(dst.Quadrs, src.Quadrs)
+	// Configuration
+	dst.config = src.config
+	// Spatial hash of points is not shared with copy
+	dst.index = nil
 	return
 }
 
 // Mirror return mirror of model
 func (m Model) Mirror(p1, p2 Point) (mir Model, err error) {
 	mir = m.Copy()
-	mir.Points, err = MirrorPoint(p1, p2, mir.Points...)
+	mir.Points, err = mir.tolerance().MirrorPoint(p1, p2, mir.Points...)
 	for i := range mir.Triangles {
 		t := &mir.Triangles[i]
 		t[0], t[1] = t[1], t[0]
@@ -121,97 +134,27 @@ func (m Model) String() string {
 	return str
 }
 
-// Dxf return string in dxf drawing format
-// https://images.autodesk.com/adsk/files/autocad_2012_pdf_dxf-reference_enu.pdf
-func (m Model) Dxf() string {
-	// create buffer
-	var buf bytes.Buffer
-
-	// start dxf
-	fmt.Fprintf(&buf, "0\nSECTION\n")
-	fmt.Fprintf(&buf, "2\nENTITIES\n")
-
-	line := func(st, en Point, layer string) {
-		fmt.Fprintf(&buf, "0\nLINE\n")
-		fmt.Fprintf(&buf, "8\n%s\n", layer) // layer
-		fmt.Fprintf(&buf, "10\n%f\n", st.X) // start point X
-		fmt.Fprintf(&buf, "20\n%f\n", st.Y) // start point Y
-		fmt.Fprintf(&buf, "30\n%f\n", 0.0)  // start point Z
-		fmt.Fprintf(&buf, "11\n%f\n", en.X) // end point X
-		fmt.Fprintf(&buf, "21\n%f\n", en.Y) // end point Y
-		fmt.Fprintf(&buf, "31\n%f\n", 0.0)  // end point Z
-	}
-
-	// TODO
-	// text := func(str string, p1, p2 Point) {
-	// }
-
-	if 1 < len(m.Points) {
-		// draw convex
-		{
-			_, cps := ConvexHull(m.Points, true)
-			for i := 1; i < len(cps); i++ {
-				line(cps[i-1], cps[i], "convex")
-			}
-			line(cps[len(cps)-1], cps[0], "convex")
-		}
-		// draw lines
-		for i := range m.Lines {
-			name := fmt.Sprintf("lines%+2d", m.Lines[i][2])
-			line(m.Points[m.Lines[i][0]], m.Points[m.Lines[i][1]], name)
-		}
-		// draw arc
-		for i := range m.Arcs {
-			name := fmt.Sprintf("arcs%+2d", m.Arcs[i][3])
-			line(m.Points[m.Arcs[i][0]], m.Points[m.Arcs[i][1]], name)
-			line(m.Points[m.Arcs[i][1]], m.Points[m.Arcs[i][2]], name)
-		}
-		// draw triangles
-		for i := range m.Triangles {
-			name := fmt.Sprintf("triangles%+2d", m.Triangles[i][3])
-			line(m.Points[m.Triangles[i][0]], m.Points[m.Triangles[i][1]], name)
-			line(m.Points[m.Triangles[i][1]], m.Points[m.Triangles[i][2]], name)
-			line(m.Points[m.Triangles[i][2]], m.Points[m.Triangles[i][0]], name)
-		}
-		// draw quadrs
-		for i := range m.Quadrs {
-			name := fmt.Sprintf("quadrs%+2d", m.Quadrs[i][4])
-			line(m.Points[m.Quadrs[i][0]], m.Points[m.Quadrs[i][1]], name)
-			line(m.Points[m.Quadrs[i][1]], m.Points[m.Quadrs[i][2]], name)
-			line(m.Points[m.Quadrs[i][2]], m.Points[m.Quadrs[i][3]], name)
-			line(m.Points[m.Quadrs[i][3]], m.Points[m.Quadrs[i][0]], name)
-		}
-	}
-
-	// end dxf
-	fmt.Fprintf(&buf, "0\nENDSEC\n")
-	fmt.Fprintf(&buf, "0\nEOF\n")
-
-	return buf.String()
-}
-
 // AddPoint return index in model slice point
 func (m *Model) AddPoint(p Point) (index int) {
-	if math.Abs(p.X) < Eps {
+	tol := m.tolerance()
+	if math.Abs(p.X) < tol.eps() {
 		p.X = 0
 	}
-	if math.Abs(p.Y) < Eps {
+	if math.Abs(p.Y) < tol.eps() {
 		p.Y = 0
 	}
 	// search in exist points
-	for i := range m.Points {
-		if p.X == m.Points[i].X && p.Y == m.Points[i].Y {
-			return i
-		}
-	}
-	for i := range m.Points {
-		if SamePoints(p, m.Points[i]) {
-			return i
-		}
+	if index = m.searchPoint(p); 0 <= index {
+		return
 	}
 	// new point
 	m.Points = append(m.Points, p)
-	return len(m.Points) - 1
+	index = len(m.Points) - 1
+	if idx := m.index; idx != nil && idx.n == index {
+		idx.append(p)
+		idx.first = &m.Points[0]
+	}
+	return
 }
 
 // AddLine add line into model with specific tag
@@ -247,7 +190,8 @@ func (m *Model) AddMultiline(tag int, ps ...Point) {
 
 // AddArc add arc into model with specific tag
 func (m *Model) AddArc(start, middle, end Point, tag int) {
-	if Orientation(start, middle, end) == CollinearPoints {
+	tol := m.tolerance()
+	if tol.Orientation(start, middle, end) == CollinearPoints {
 		// Problem: after splitting arc possible too
 		// small then arc as like line
 		m.AddLine(start, end, tag)
@@ -273,8 +217,9 @@ func (m *Model) AddArc(start, middle, end Point, tag int) {
 
 // AddTriangle add triangle into model with specific tag/material
 func (m *Model) AddTriangle(start, middle, end Point, tag int) {
-	if Debug {
-		if Orientation(start, middle, end) == CollinearPoints {
+	tol := m.tolerance()
+	if m.debug() {
+		if tol.Orientation(start, middle, end) == CollinearPoints {
 			panic(fmt.Errorf("%.6e %.6e %.6e", start, middle, end))
 		}
 	}
@@ -336,85 +281,145 @@ func (m *Model) AddModel(from Model) {
 	}
 }
 
-// Intersection change model with finding all model intersections
-func (m *Model) Intersection() {
-	// value `ai` is amount of intersections
-	boxX := func(ps []Point) (min, max float64) {
-		min = ps[0].X
-		max = ps[0].X
-		for i := 1; i < len(ps); i++ {
-			min = math.Min(min, ps[i].X)
-			max = math.Max(max, ps[i].X)
+// Intersection change model with finding all model intersections.
+// For zero length lines error `ErrZeroLengthSegment` is returned.
+// For arcs with same or collinear points error `ErrNotValidArc` is
+// returned.
+func (m *Model) Intersection() error {
+	return m.intersection(nil, newRtree)
+}
+
+// crossing is precalculated intersection of element with other element
+type crossing struct {
+	index    int     // index of other element
+	pi       []Point // intersection points
+	stA, stB State
+	res      [][3]Point // parts of split triangle
+	err      error
+}
+
+// intersection change model with finding all model intersections.
+// Candidates for intersection are found by R-tree created by function.
+// Intersections of existed elements are calculated by workers and model
+// is changed in order of elements.
+func (m *Model) intersection(p *progress, newTree func(boxes []box) *rtree) (err error) {
+	tol := m.tolerance()
+	workers := m.workers()
+	// error of zero length line
+	zeroLine := func(i int) error {
+		return &ElementError{
+			Err:    ErrZeroLengthSegment,
+			Index:  i,
+			Points: []int{m.Lines[i][0], m.Lines[i][1]},
+			Coords: []Point{m.Points[m.Lines[i][0]], m.Points[m.Lines[i][1]]},
 		}
-		return
 	}
-	boxY := func(ps []Point) (min, max float64) {
-		min = ps[0].Y
-		max = ps[0].Y
-		for i := 1; i < len(ps); i++ {
-			min = math.Min(min, ps[i].Y)
-			max = math.Max(max, ps[i].Y)
+	// error of arc with zero length
+	notValidArc := func(i int) error {
+		return &ElementError{
+			Err:    ErrNotValidArc,
+			Index:  i,
+			Points: []int{m.Arcs[i][0], m.Arcs[i][1], m.Arcs[i][2]},
+			Coords: []Point{
+				m.Points[m.Arcs[i][0]],
+				m.Points[m.Arcs[i][1]],
+				m.Points[m.Arcs[i][2]],
+			},
 		}
-		return
 	}
-
-	boxIntersect := func(A, B []Point) bool {
-		Axin, Axax := boxX(A)
-		Bxin, Bxax := boxX(B)
-		if Axax < Bxin || Bxax < Axin {
-			return false
+	// value `ai` is amount of intersections
+	// bounding boxes of elements
+	lineBox := func(i int) box {
+		return boxOf(m.Points[m.Lines[i][0]], m.Points[m.Lines[i][1]])
+	}
+	arcBox := func(i int) box {
+		return boxOf(m.Points[m.Arcs[i][0]], m.Points[m.Arcs[i][1]], m.Points[m.Arcs[i][2]])
+	}
+	triangleBox := func(i int) box {
+		return boxOf(
+			m.Points[m.Triangles[i][0]],
+			m.Points[m.Triangles[i][1]],
+			m.Points[m.Triangles[i][2]],
+		)
+	}
+	// R-tree of elements
+	tree := func(size int, b func(i int) box) *rtree {
+		boxes := make([]box, size)
+		for i := range boxes {
+			boxes[i] = b(i)
 		}
-
-		Ayin, Ayax := boxY(A)
-		Byin, Byax := boxY(B)
-		if Ayax < Byin || Byax < Ayin {
-			return false
+		return newTree(boxes)
+	}
+	// precalculated intersections for indexes less size. For other
+	// indexes intersections are calculated by demand.
+	precalculate := func(size int, find func(i int) []crossing) func(i int) []crossing {
+		found := make([][]crossing, size)
+		parallel(workers, size, func(i int) {
+			if p.done() == nil {
+				found[i] = find(i)
+			}
+		})
+		return func(i int) []crossing {
+			if i < size {
+				return found[i]
+			}
+			return find(i)
 		}
-
-		// try or may-be
-		return true
 	}
 
 	// find intersections
-	fs := []func() int{
+	fs := []func() (int, error){
 		// line-line intersection
-		func() (ai int) {
+		func() (ai int, err error) {
 			var (
 				intersect = make([]bool, len(m.Lines))
 				size      = len(m.Lines)
-				s1        = make([]Point, 2)
-				s2        = make([]Point, 2)
+				lines     = tree(size, lineBox)
+				crossings = precalculate(size, func(il int) (cs []crossing) {
+					for _, jl := range lines.search(lines.boxes[il]) {
+						if il <= jl {
+							continue
+						}
+						// analyse
+						pi, stA, stB := tol.LineLine(
+							m.Points[m.Lines[il][0]], m.Points[m.Lines[il][1]],
+							m.Points[m.Lines[jl][0]], m.Points[m.Lines[jl][1]],
+						)
+						// no intersections
+						if 0 == len(pi) {
+							continue
+						}
+						cs = append(cs, crossing{index: jl, pi: pi, stA: stA, stB: stB})
+					}
+					return
+				})
 			)
 			for il := 0; il < size; il++ {
-				for jl := 0; jl < size; jl++ {
+				if err = p.done(); err != nil {
+					return
+				}
+				if intersect[il] {
+					continue
+				}
+				for _, c := range crossings(il) {
+					jl, pi, stA, stB := c.index, c.pi, c.stA, c.stB
 					// ignore intersection lines
-					if il <= jl || intersect[il] || intersect[jl] {
+					if intersect[il] || intersect[jl] {
 						continue
 					}
-					s1[0] = m.Points[m.Lines[il][0]]
-					s1[1] = m.Points[m.Lines[il][1]]
-					s2[0] = m.Points[m.Lines[jl][0]]
-					s2[1] = m.Points[m.Lines[jl][1]]
-					if !boxIntersect(s1, s2) {
-						continue
+					// not acceptable zero length lines
+					if stA.Has(ZeroLengthSegment) {
+						err = zeroLine(il)
+						return
 					}
-					// analyse
-					pi, stA, stB := LineLine(
-						m.Points[m.Lines[il][0]], m.Points[m.Lines[il][1]],
-						m.Points[m.Lines[jl][0]], m.Points[m.Lines[jl][1]],
-					)
-					// no intersections
-					if 0 == len(pi) {
-						continue
+					if stB.Has(ZeroLengthSegment) {
+						err = zeroLine(jl)
+						return
 					}
 					// debug test
 					if 1 < len(pi) {
-						panic("not valid")
-					}
-					// not acceptable zero length lines
-					if stA.Has(ZeroLengthSegment) ||
-						stB.Has(ZeroLengthSegment) {
-						panic(fmt.Errorf("zero lenght error"))
+						err = fmt.Errorf("not valid amount of intersection points: %v", pi)
+						return
 					}
 
 					if stA.Has(OnPoint0Segment) && stA.Has(OnPoint1Segment) &&
@@ -459,31 +464,21 @@ func (m *Model) Intersection() {
 		},
 
 		// arc-line intersection
-		func() (ai int) {
+		func() (ai int, err error) {
 			var (
 				intersectLines = make([]bool, len(m.Lines))
 				intersectArcs  = make([]bool, len(m.Arcs))
 				sizeLines      = len(m.Lines)
 				sizeArcs       = len(m.Arcs)
-				s1             = make([]Point, 2)
-				s2             = make([]Point, 3)
+				arcs           = tree(sizeArcs, arcBox)
 			)
-			for il := 0; il < sizeLines; il++ {
-				for ja := 0; ja < sizeArcs; ja++ {
-					// ignore intersection lines
-					if intersectLines[il] || intersectArcs[ja] {
-						continue
-					}
-					s1[0] = m.Points[m.Lines[il][0]]
-					s1[1] = m.Points[m.Lines[il][1]]
-					s2[0] = m.Points[m.Arcs[ja][0]]
-					s2[1] = m.Points[m.Arcs[ja][1]]
-					s2[2] = m.Points[m.Arcs[ja][2]]
-					if !boxIntersect(s1, s2) {
-						continue
-					}
+			if sizeArcs == 0 {
+				return
+			}
+			crossings := precalculate(sizeLines, func(il int) (cs []crossing) {
+				for _, ja := range arcs.search(lineBox(il)) {
 					// analyse
-					pi, stA, stB := LineArc(
+					pi, stA, stB := tol.LineArc(
 						// Line
 						m.Points[m.Lines[il][0]], m.Points[m.Lines[il][1]],
 						// Arc
@@ -491,10 +486,28 @@ func (m *Model) Intersection() {
 						m.Points[m.Arcs[ja][1]],
 						m.Points[m.Arcs[ja][2]],
 					)
+					cs = append(cs, crossing{index: ja, pi: pi, stA: stA, stB: stB})
+				}
+				return
+			})
+			for il := 0; il < sizeLines; il++ {
+				if err = p.done(); err != nil {
+					return
+				}
+				for _, c := range crossings(il) {
+					ja, pi, stA, stB := c.index, c.pi, c.stA, c.stB
+					// ignore intersection lines
+					if intersectLines[il] || intersectArcs[ja] {
+						continue
+					}
 					// not acceptable zero length lines
-					if stA.Has(ZeroLengthSegment) ||
-						stB.Has(ZeroLengthSegment) {
-						panic(fmt.Errorf("zero lenght error"))
+					if stA.Has(ZeroLengthSegment) {
+						err = zeroLine(il)
+						return
+					}
+					if stB.Has(ZeroLengthSegment) {
+						err = notValidArc(ja)
+						return
 					}
 					// intersection on line A
 					//
@@ -509,7 +522,7 @@ func (m *Model) Intersection() {
 					same1:
 						for i := range roots {
 							for j := 0; j < 2; j++ {
-								if Distance(roots[i], m.Points[m.Lines[il][j]]) < Eps {
+								if Distance(roots[i], m.Points[m.Lines[il][j]]) < tol.eps() {
 									roots = append(roots[:i], roots[i+1:]...)
 									goto same1
 								}
@@ -573,7 +586,8 @@ func (m *Model) Intersection() {
 									}
 								}
 							default:
-								panic("not valid")
+								err = fmt.Errorf("not valid amount of roots: %v", roots)
+								return
 							}
 						}
 					}
@@ -584,19 +598,17 @@ func (m *Model) Intersection() {
 					//
 					if stB.Has(OnSegment) {
 						tag := m.Arcs[ja][3]
-						res, err := ArcSplitByPoint(
+						res, err := tol.ArcSplitByPoint(
 							m.Points[m.Arcs[ja][0]],
 							m.Points[m.Arcs[ja][1]],
 							m.Points[m.Arcs[ja][2]],
 							pi...)
 						if err != nil {
-							// TODO	panic(err)
-							// err = nil
-						} else {
-							for i := range res {
-								intersectArcs[ja] = true
-								m.AddArc(res[i][0], res[i][1], res[i][2], tag)
-							}
+							return ai, notValidArc(ja)
+						}
+						for i := range res {
+							intersectArcs[ja] = true
+							m.AddArc(res[i][0], res[i][1], res[i][2], tag)
 						}
 					}
 				}
@@ -621,22 +633,43 @@ func (m *Model) Intersection() {
 		},
 
 		// point-arc intersection
-		func() (ai int) {
+		func() (ai int, err error) {
 			var (
 				intersectArcs = make([]bool, len(m.Arcs))
 				sizeArcs      = len(m.Arcs)
-				s1            = make([]Point, 1)
-				s2            = make([]Point, 3)
+				arcs          = tree(sizeArcs, arcBox)
 			)
+			if sizeArcs == 0 {
+				return
+			}
+			crossings := precalculate(len(m.Points), func(ip int) (cs []crossing) {
+				for _, ja := range arcs.search(boxOf(m.Points[ip])) {
+					// analyse
+					pi, _, stB := tol.PointArc(
+						// Point
+						m.Points[ip],
+						// Arc
+						m.Points[m.Arcs[ja][0]],
+						m.Points[m.Arcs[ja][1]],
+						m.Points[m.Arcs[ja][2]],
+					)
+					cs = append(cs, crossing{index: ja, pi: pi, stB: stB})
+				}
+				return
+			})
 			for ip := 0; ip < len(m.Points); ip++ {
-				for ja := 0; ja < sizeArcs; ja++ {
+				if err = p.done(); err != nil {
+					return
+				}
+				for _, c := range crossings(ip) {
+					ja, pi, stB := c.index, c.pi, c.stB
 					// ignore intersection lines
 					if intersectArcs[ja] {
 						continue
 					}
 					// ignore arc middle points only if not by another
 					// line or arc
-					if Distance(m.Points[m.Arcs[ja][1]], m.Points[ip]) < Eps {
+					if Distance(m.Points[m.Arcs[ja][1]], m.Points[ip]) < tol.eps() {
 						ignore := true
 						for i := range m.Lines {
 							if m.Lines[i][0] == ip || m.Lines[i][1] == ip {
@@ -655,26 +688,10 @@ func (m *Model) Intersection() {
 							continue
 						}
 					}
-					s1[0] = m.Points[ip]
-					s2[0] = m.Points[m.Arcs[ja][0]]
-					s2[1] = m.Points[m.Arcs[ja][1]]
-					s2[2] = m.Points[m.Arcs[ja][2]]
-					if !boxIntersect(s1, s2) {
-						continue
-					}
-
-					// analyse
-					pi, _, stB := PointArc(
-						// Point
-						m.Points[ip],
-						// Arc
-						m.Points[m.Arcs[ja][0]],
-						m.Points[m.Arcs[ja][1]],
-						m.Points[m.Arcs[ja][2]],
-					)
-					// not acceptable zero length lines
+					// not acceptable zero length arcs
 					if stB.Has(ZeroLengthSegment) {
-						panic(fmt.Errorf("zero lenght error"))
+						err = notValidArc(ja)
+						return
 					}
 					// intersection on arc B
 					//
@@ -683,19 +700,17 @@ func (m *Model) Intersection() {
 					//
 					if stB.Has(OnSegment) && 0 < len(pi) {
 						tag := m.Arcs[ja][3]
-						res, err := ArcSplitByPoint(
+						res, err := tol.ArcSplitByPoint(
 							m.Points[m.Arcs[ja][0]],
 							m.Points[m.Arcs[ja][1]],
 							m.Points[m.Arcs[ja][2]],
 							pi...)
 						if err != nil {
-							// TODO	panic(err)
-							// err = nil
-						} else {
-							for i := range res {
-								intersectArcs[ja] = true
-								m.AddArc(res[i][0], res[i][1], res[i][2], tag)
-							}
+							return ai, notValidArc(ja)
+						}
+						for i := range res {
+							intersectArcs[ja] = true
+							m.AddArc(res[i][0], res[i][1], res[i][2], tag)
 						}
 					}
 				}
@@ -712,36 +727,43 @@ func (m *Model) Intersection() {
 		},
 
 		// point-line intersection
-		func() (ai int) {
+		func() (ai int, err error) {
 			var (
 				intersectLines = make([]bool, len(m.Lines))
 				sizeLines      = len(m.Lines)
-				s1             = make([]Point, 1)
-				s2             = make([]Point, 2)
+				lines          = tree(sizeLines, lineBox)
 			)
-			for ip := 0; ip < len(m.Points); ip++ {
-				for ja := 0; ja < sizeLines; ja++ {
-					// ignore intersection lines
-					if intersectLines[ja] {
-						continue
-					}
-					s1[0] = m.Points[ip]
-					s2[0] = m.Points[m.Lines[ja][0]]
-					s2[1] = m.Points[m.Lines[ja][1]]
-					if !boxIntersect(s1, s2) {
-						continue
-					}
+			if sizeLines == 0 {
+				return
+			}
+			crossings := precalculate(len(m.Points), func(ip int) (cs []crossing) {
+				for _, ja := range lines.search(boxOf(m.Points[ip])) {
 					// analyse
-					pi, _, stB := PointLine(
+					pi, _, stB := tol.PointLine(
 						// Point
 						m.Points[ip],
-						// Arc
+						// Line
 						m.Points[m.Lines[ja][0]],
 						m.Points[m.Lines[ja][1]],
 					)
+					cs = append(cs, crossing{index: ja, pi: pi, stB: stB})
+				}
+				return
+			})
+			for ip := 0; ip < len(m.Points); ip++ {
+				if err = p.done(); err != nil {
+					return
+				}
+				for _, c := range crossings(ip) {
+					ja, pi, stB := c.index, c.pi, c.stB
+					// ignore intersection lines
+					if intersectLines[ja] {
+						continue
+					}
 					// not acceptable zero length lines
 					if stB.Has(ZeroLengthSegment) {
-						panic(fmt.Errorf("zero lenght error for line: %v", m.Lines[ja]))
+						err = zeroLine(ja)
+						return
 					}
 					// intersection on line B
 					//
@@ -773,28 +795,18 @@ func (m *Model) Intersection() {
 		// TODO
 
 		// point-triangle intersection
-		func() (ai int) {
+		func() (ai int, err error) {
 			var (
 				intersectTr = make([]bool, len(m.Triangles))
 				sizeTrs     = len(m.Triangles)
-				s1          = make([]Point, 1)
-				s2          = make([]Point, 3)
+				triangles   = tree(sizeTrs, triangleBox)
 			)
-			for ip := 0; ip < len(m.Points); ip++ {
-				for jt := 0; jt < sizeTrs; jt++ {
-					// ignore intersection lines
-					if intersectTr[jt] {
-						continue
-					}
-					s1[0] = m.Points[ip]
-					s2[0] = m.Points[m.Triangles[jt][0]]
-					s2[1] = m.Points[m.Triangles[jt][1]]
-					s2[2] = m.Points[m.Triangles[jt][2]]
-					if !boxIntersect(s1, s2) {
-						continue
-					}
-					tag := m.Triangles[jt][3]
-					res, _, err := TriangleSplitByPoint(
+			if sizeTrs == 0 {
+				return
+			}
+			crossings := precalculate(len(m.Points), func(ip int) (cs []crossing) {
+				for _, jt := range triangles.search(boxOf(m.Points[ip])) {
+					res, _, err := tol.TriangleSplitByPoint(
 						// Point
 						m.Points[ip],
 						// Triangle
@@ -802,6 +814,21 @@ func (m *Model) Intersection() {
 						m.Points[m.Triangles[jt][1]],
 						m.Points[m.Triangles[jt][2]],
 					)
+					cs = append(cs, crossing{index: jt, res: res, err: err})
+				}
+				return
+			})
+			for ip := 0; ip < len(m.Points); ip++ {
+				if err = p.done(); err != nil {
+					return
+				}
+				for _, c := range crossings(ip) {
+					jt, res, err := c.index, c.res, c.err
+					// ignore intersection lines
+					if intersectTr[jt] {
+						continue
+					}
+					tag := m.Triangles[jt][3]
 					if err != nil {
 						// TODO	panic(err)
 						// err = nil
@@ -824,18 +851,33 @@ func (m *Model) Intersection() {
 			return
 		},
 	}
+	// arcs with same or collinear points
+	for i, a := range m.Arcs {
+		if tol.Orientation(m.Points[a[0]], m.Points[a[1]], m.Points[a[2]]) == CollinearPoints {
+			return notValidArc(i)
+		}
+	}
 	for iter := 0; ; iter++ {
+		if err = p.report("Intersection", iter, 0); err != nil {
+			return
+		}
 		ai := 0
 		for i := range fs {
-			ai += fs[i]()
+			var a int
+			if a, err = fs[i](); err != nil {
+				return
+			}
+			ai += a
 		}
 		if ai == 0 {
 			break
 		}
 		if iter == 1000 {
-			panic("too many intersections")
+			err = fmt.Errorf("too many iterations of intersections")
+			return
 		}
 	}
+	return
 }
 
 // Merge `from` model to `to` model
@@ -873,6 +915,7 @@ func (m *Model) Rotate(xc, yc, angle float64) {
 	for i := range m.Points {
 		m.Points[i] = Rotate(xc, yc, angle, m.Points[i])
 	}
+	m.resetIndex()
 }
 
 // Move all points of model
@@ -883,6 +926,7 @@ func (m *Model) Move(dx, dy float64) {
 			Y: m.Points[i].Y + dy,
 		}
 	}
+	m.resetIndex()
 }
 
 // RemovePoint removed point in accoding to function `filter`
@@ -934,6 +978,7 @@ func (m *Model) removePointByIndex(remove ...int) {
 	if len(remove) == 0 {
 		return
 	}
+	defer m.resetIndex()
 	// sort
 	sort.Ints(remove)
 	// check
@@ -1020,19 +1065,70 @@ func (m *Model) removePointByIndex(remove ...int) {
 	}
 }
 
-// Split all model lines, arcs by distance `d`
-func (m *Model) Split(d float64) {
-	if d <= 0 {
-		panic("negative or zero split distance")
+// Split all model lines, arcs by distance `d`.
+// Split elements are calculated by workers, see `Config`.
+// Model is not changed, if error is returned.
+func (m *Model) Split(d float64) (err error) {
+	tol := m.tolerance()
+	workers := m.workers()
+	if !(0 < d) {
+		err = fmt.Errorf("not valid split distance: %v", d)
+		return
+	}
+	// parts of split arcs
+	sizeArcs := len(m.Arcs)
+	parts := make([][][3]Point, sizeArcs)
+	errs := make([]error, sizeArcs)
+	parallel(workers, sizeArcs, func(ia int) {
+		arcs := [][3]Point{{
+			m.Points[m.Arcs[ia][0]],
+			m.Points[m.Arcs[ia][1]],
+			m.Points[m.Arcs[ia][2]],
+		}}
+
+		for iter := 0; iter < 100; iter++ {
+			// preliminary calculation arc length
+			distance := 2.0 * Distance(arcs[len(arcs)-1][0], arcs[len(arcs)-1][1])
+			if distance <= d {
+				break
+			}
+			arcs2 := [][3]Point{}
+			for i := range arcs {
+				res, err := tol.ArcSplitByPoint(arcs[i][0], arcs[i][1], arcs[i][2])
+				if err != nil {
+					errs[ia] = &ElementError{
+						Err:    ErrNotValidArc,
+						Index:  ia,
+						Points: []int{m.Arcs[ia][0], m.Arcs[ia][1], m.Arcs[ia][2]},
+						Coords: arcs[i][:],
+					}
+					return
+				}
+				arcs2 = append(arcs2, res...)
+			}
+			arcs = arcs2
+		}
+
+		if len(arcs) == 1 {
+			return
+		}
+		parts[ia] = arcs
+	})
+	for _, err = range errs {
+		if err != nil {
+			return
+		}
 	}
 	{
 		// split lines
 		size := len(m.Lines)
 		split := make([]bool, size)
-		for il := 0; il < size; il++ {
+		// points of split lines
+		points := make([][]Point, size)
+		parallel(workers, size, func(il int) {
 			distance := Distance(m.Points[m.Lines[il][0]], m.Points[m.Lines[il][1]])
 			if distance <= d {
-				continue
+				return
 			}
 			split[il] = true
 			var (
@@ -1042,19 +1138,18 @@ func (m *Model) Split(d float64) {
 				dx = (m.Points[m.Lines[il][1]].X - m.Points[m.Lines[il][0]].X) / float64(am)
 				dy = (m.Points[m.Lines[il][1]].Y - m.Points[m.Lines[il][0]].Y) / float64(am)
 			)
+			points[il] = make([]Point, am+1)
+			for i := 0; i <= am; i++ {
+				points[il][i] = Point{
+					X: m.Points[m.Lines[il][0]].X + dx*float64(i),
+					Y: m.Points[m.Lines[il][0]].Y + dy*float64(i),
+				}
+			}
+		})
+		for il := 0; il < size; il++ {
 			// add new lines
-			for i := 1; i <= am; i++ {
-				m.AddLine(
-					Point{
-						X: m.Points[m.Lines[il][0]].X + dx*float64(i-1),
-						Y: m.Points[m.Lines[il][0]].Y + dy*float64(i-1),
-					},
-					Point{
-						X: m.Points[m.Lines[il][0]].X + dx*float64(i),
-						Y: m.Points[m.Lines[il][0]].Y + dy*float64(i),
-					},
-					m.Lines[il][2],
-				)
+			for i := 1; i < len(points[il]); i++ {
+				m.AddLine(points[il][i-1], points[il][i], m.Lines[il][2])
 			}
 		}
 		// remove split lines
@@ -1067,49 +1162,21 @@ func (m *Model) Split(d float64) {
 	}
 	{
 		// split arcs
-		size := len(m.Arcs)
-		split := make([]bool, size)
-		for ia := 0; ia < size; ia++ {
-			arcs := [][3]Point{{
-				m.Points[m.Arcs[ia][0]],
-				m.Points[m.Arcs[ia][1]],
-				m.Points[m.Arcs[ia][2]],
-			}}
-
-			for iter := 0; iter < 100; iter++ {
-				// preliminary calculation arc length
-				distance := 2.0 * Distance(arcs[len(arcs)-1][0], arcs[len(arcs)-1][1])
-				if distance <= d {
-					break
-				}
-				arcs2 := [][3]Point{}
-				for i := range arcs {
-					res, err := ArcSplitByPoint(arcs[i][0], arcs[i][1], arcs[i][2])
-					if err != nil {
-						panic(fmt.Errorf("Arc: %v", arcs[len(arcs)-1]))
-					}
-					arcs2 = append(arcs2, res...)
-				}
-				arcs = arcs2
-			}
-
-			if len(arcs) == 1 {
-				continue
-			}
-			split[ia] = true
+		for ia := 0; ia < sizeArcs; ia++ {
 			// add new arcs
-			for i := range arcs {
-				m.AddArc(arcs[i][0], arcs[i][1], arcs[i][2], m.Arcs[ia][3])
+			for _, arc := range parts[ia] {
+				m.AddArc(arc[0], arc[1], arc[2], m.Arcs[ia][3])
 			}
 		}
-		// remove split lines
-		for ia := size - 1; 0 <= ia; ia-- {
-			if !split[ia] {
+		// remove split arcs
+		for ia := sizeArcs - 1; 0 <= ia; ia-- {
+			if parts[ia] == nil {
 				continue
 			}
 			m.Arcs = append(m.Arcs[:ia], m.Arcs[ia+1:]...)
 		}
 	}
+	return
 }
 
 // MinPointDistance return minimal between 2 points
@@ -1150,24 +1217,24 @@ func (m *Model) ArcsToLines() {
 
 // ConvexHullTriangles add triangles of model convex hull
 func (m *Model) ConvexHullTriangles() {
-	_, cps := ConvexHull(m.Points, true) // points on convex hull
+	tol := m.tolerance()
+	_, cps := tol.ConvexHull(m.Points, true) // points on convex hull
 	for i := 2; i < len(cps); i++ {
 		m.AddTriangle(cps[0], cps[i-2], cps[i-1], -1)
 	}
 }
 
-// Write model into file with filename in JSON format
+// Write model into file with filename in indented JSON format
 func (m Model) Write(filename string) (err error) {
-	out, err := m.JSON()
+	f, err := os.Create(filename)
 	if err != nil {
 		return
 	}
-	// write into file
-	err = os.WriteFile(filename, []byte(out), 0666)
-	if err != nil {
+	if err = m.Encode(f, JSONIndent); err != nil {
+		_ = f.Close()
 		return
 	}
-	return nil
+	return f.Close()
 }
 
 // JSON convert model in JSON format
@@ -1185,24 +1252,19 @@ func (m Model) JSON() (_ string, err error) {
 	return buf.String(), nil
 }
 
-// Read model from file with filename in JSON format
+// Read model from file with filename in any format of `Encode`
 func (m *Model) Read(filename string) (err error) {
-	// read our opened file as a byte array.
-	var dat []byte
-	dat, err = os.ReadFile(filename)
+	f, err := os.Open(filename)
 	if err != nil {
 		return
 	}
-	if len(dat) == 0 {
-		err = fmt.Errorf("file `%s` is empty", filename)
-		return
-	}
-	// we unmarshal our data which contains our slice
-	err = json.Unmarshal(dat, m)
-	if err != nil {
-		return
+	defer func() {
+		_ = f.Close()
+	}()
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		return fmt.Errorf("file `%s` is empty", filename)
 	}
-	return
+	return m.Decode(f)
 }
 
 // Combine triangles to quadr with same tag
@@ -1211,6 +1273,7 @@ func (m *Model) Read(filename string) (err error) {
 //
 // Recommendation value is 1.05
 func (m *Model) Combine(factorOneLine float64) (err error) {
+	tol := m.tolerance()
 	cases := [][6]int{
 		// side0 - side0
 		{0, 1, 2, 0, 1, 2},
@@ -1261,7 +1324,7 @@ func (m *Model) Combine(factorOneLine float64) (err error) {
 					m.Triangles[i][c[1]] == m.Triangles[j][c[4]] {
 					// intersect by side
 					var res [][3]Point
-					res, _, err = TriangleSplitByPoint(
+					res, _, err = tol.TriangleSplitByPoint(
 						m.Points[m.Triangles[i][c[1]]],
 						m.Points[m.Triangles[i][c[0]]],
 						m.Points[m.Triangles[i][c[2]]],
@@ -1272,7 +1335,7 @@ func (m *Model) Combine(factorOneLine float64) (err error) {
 					if len(res) == 3 {
 						continue
 					}
-					res, _, err = TriangleSplitByPoint(
+					res, _, err = tol.TriangleSplitByPoint(
 						m.Points[m.Triangles[i][c[1]]],
 						m.Points[m.Triangles[j][c[5]]],
 						m.Points[m.Triangles[i][c[2]]],
@@ -1283,7 +1346,7 @@ func (m *Model) Combine(factorOneLine float64) (err error) {
 					if len(res) == 3 {
 						continue
 					}
-					res, _, err = TriangleSplitByPoint(
+					res, _, err = tol.TriangleSplitByPoint(
 						m.Points[m.Triangles[i][c[0]]],
 						m.Points[m.Triangles[i][c[2]]],
 						m.Points[m.Triangles[i][c[1]]],
@@ -1294,7 +1357,7 @@ func (m *Model) Combine(factorOneLine float64) (err error) {
 					if len(res) == 3 {
 						continue
 					}
-					res, _, err = TriangleSplitByPoint(
+					res, _, err = tol.TriangleSplitByPoint(
 						m.Points[m.Triangles[i][c[0]]],
 						m.Points[m.Triangles[j][c[5]]],
 						m.Points[m.Triangles[i][c[1]]],
@@ -1341,7 +1404,7 @@ func (m *Model) Combine(factorOneLine float64) (err error) {
 	removedTriangles := make([]bool, len(m.Triangles))
 	for i := range quadrs {
 		q := quadrs[i]
-		if math.Abs(q.onOneLine-1.0) < Eps {
+		if math.Abs(q.onOneLine-1.0) < tol.eps() {
 			continue
 		}
 		if q.onOneLine < factorOneLine {
@@ -1353,7 +1416,7 @@ func (m *Model) Combine(factorOneLine float64) (err error) {
 		if removedTriangles[q.triangles[1]] {
 			continue
 		}
-		if Log {
+		if m.log() {
 			log.Printf("Combine: %#v", q)
 		}
 		removedTriangles[q.triangles[0]] = true
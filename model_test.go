@@ -63,9 +63,13 @@ func TestModel(t *testing.T) {
 	m.AddLine(Point{0, -1}, Point{0, 1}, 3)
 	fmt.Fprintf(&buf, "Only structural lines:\n%s", m)
 	view() // 0
-	m.Intersection()
+	if err := m.Intersection(); err != nil {
+		t.Fatal(err)
+	}
 	view() // 1
-	m.Split(0.2)
+	if err := m.Split(0.2); err != nil {
+		t.Fatal(err)
+	}
 	view() // 2
 	m.ArcsToLines()
 	view() // 3
@@ -73,7 +77,9 @@ func TestModel(t *testing.T) {
 	view() // 4
 	m.ConvexHullTriangles()
 	view() // 5
-	m.Intersection()
+	if err := m.Intersection(); err != nil {
+		t.Fatal(err)
+	}
 	view() // 6
 	m.RemoveEmptyPoints()
 	view() // 7
@@ -0,0 +1,591 @@
+package gog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// MshVersion is version of gmsh ASCII file format
+type MshVersion int
+
+const (
+	// Msh22 is gmsh ASCII file format version 2.2
+	Msh22 MshVersion = iota
+
+	// Msh41 is gmsh ASCII file format version 4.1
+	Msh41
+)
+
+// types of gmsh elements
+const (
+	mshLine     = 1 // 2-node line
+	mshTriangle = 2 // 3-node triangle
+	mshQuadr    = 3 // 4-node quadrangle
+	mshArc      = 8 // 3-node second order line
+	mshPoint    = 15
+)
+
+// mshElement is element of gmsh file with physical tag
+type mshElement struct {
+	typ   int
+	tag   int
+	nodes []int // index of points
+}
+
+// mshElements return all not removed elements of model.
+// Arcs are converted into 3-node second order lines.
+func (m Model) mshElements() (els []mshElement) {
+	for _, l := range m.Lines {
+		if l[0] == Removed || l[2] == Removed {
+			continue
+		}
+		els = append(els, mshElement{typ: mshLine, tag: l[2], nodes: []int{l[0], l[1]}})
+	}
+	for _, a := range m.Arcs {
+		if a[0] == Removed || a[3] == Removed {
+			continue
+		}
+		// nodes order of gmsh: start, end, middle
+		els = append(els, mshElement{typ: mshArc, tag: a[3], nodes: []int{a[0], a[2], a[1]}})
+	}
+	for _, t := range m.Triangles {
+		if t[0] == Removed {
+			continue
+		}
+		els = append(els, mshElement{typ: mshTriangle, tag: t[3], nodes: []int{t[0], t[1], t[2]}})
+	}
+	for _, q := range m.Quadrs {
+		if q[0] == Removed {
+			continue
+		}
+		els = append(els, mshElement{typ: mshQuadr, tag: q[4], nodes: []int{q[0], q[1], q[2], q[3]}})
+	}
+	return
+}
+
+// mshDim return dimension of gmsh element
+func mshDim(typ int) int {
+	switch typ {
+	case mshLine, mshArc:
+		return 1
+	case mshTriangle, mshQuadr:
+		return 2
+	}
+	return 0
+}
+
+// WriteMsh write model in gmsh ASCII file format.
+// Tags of lines, arcs, triangles, quadrs are written as physical tags.
+// Arcs are written as 3-node second order lines.
+// Gmsh is expected only positive physical tags, so error is returned
+// for element with zero or negative tag.
+//
+// https://gmsh.info/doc/texinfo/gmsh.html#MSH-file-format
+func (m Model) WriteMsh(w io.Writer, version MshVersion) (err error) {
+	var buf bytes.Buffer
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	els := m.mshElements()
+	for i, el := range els {
+		if el.tag <= 0 {
+			return fmt.Errorf("not positive physical tag %d of element %d",
+				el.tag, i+1)
+		}
+	}
+
+	switch version {
+	case Msh22:
+		fmt.Fprintf(&buf, "$MeshFormat\n2.2 0 8\n$EndMeshFormat\n")
+		// nodes
+		fmt.Fprintf(&buf, "$Nodes\n%d\n", len(m.Points))
+		for i, p := range m.Points {
+			fmt.Fprintf(&buf, "%d %s %s 0\n", i+1, f(p.X), f(p.Y))
+		}
+		fmt.Fprintf(&buf, "$EndNodes\n")
+		// elements
+		fmt.Fprintf(&buf, "$Elements\n%d\n", len(els))
+		for i, el := range els {
+			// physical and elementary tags
+			fmt.Fprintf(&buf, "%d %d 2 %d %d", i+1, el.typ, el.tag, el.tag)
+			for _, n := range el.nodes {
+				fmt.Fprintf(&buf, " %d", n+1)
+			}
+			fmt.Fprintf(&buf, "\n")
+		}
+		fmt.Fprintf(&buf, "$EndElements\n")
+
+	case Msh41:
+		fmt.Fprintf(&buf, "$MeshFormat\n4.1 0 8\n$EndMeshFormat\n")
+		// entities
+		type entity struct {
+			dim, tag int
+		}
+		var (
+			entities [3][]int // tags of entities by dimension
+			index    = map[entity]int{}
+		)
+		for _, el := range els {
+			d := mshDim(el.typ)
+			e := entity{dim: d, tag: el.tag}
+			if _, ok := index[e]; ok {
+				continue
+			}
+			entities[d] = append(entities[d], el.tag)
+			index[e] = 0
+		}
+		for d := range entities {
+			sort.Ints(entities[d])
+			for i, tag := range entities[d] {
+				index[entity{dim: d, tag: tag}] = i + 1
+			}
+		}
+		// all nodes are located in entity with maximal dimension
+		nodeEntity := entity{dim: 0, tag: 1}
+		if len(entities[0]) == 0 && len(entities[1]) == 0 && len(entities[2]) == 0 &&
+			0 < len(m.Points) {
+			entities[0] = append(entities[0], 0)
+		}
+		for d := range entities {
+			if 0 < len(entities[d]) {
+				nodeEntity = entity{dim: d, tag: 1}
+			}
+		}
+		min, max := Point{}, Point{}
+		if 0 < len(m.Points) {
+			min, max = BorderPoints2d(m.Points...)
+		}
+		fmt.Fprintf(&buf, "$Entities\n%d %d %d 0\n",
+			len(entities[0]), len(entities[1]), len(entities[2]))
+		for i := range entities[0] {
+			fmt.Fprintf(&buf, "%d %s %s 0 0\n", i+1, f(m.Points[0].X), f(m.Points[0].Y))
+		}
+		for d := 1; d < len(entities); d++ {
+			for i, tag := range entities[d] {
+				fmt.Fprintf(&buf, "%d %s %s 0 %s %s 0 1 %d 0\n",
+					i+1, f(min.X), f(min.Y), f(max.X), f(max.Y), tag)
+			}
+		}
+		fmt.Fprintf(&buf, "$EndEntities\n")
+		// nodes
+		if 0 < len(m.Points) {
+			fmt.Fprintf(&buf, "$Nodes\n1 %d 1 %d\n", len(m.Points), len(m.Points))
+			fmt.Fprintf(&buf, "%d %d 0 %d\n", nodeEntity.dim, nodeEntity.tag, len(m.Points))
+			for i := range m.Points {
+				fmt.Fprintf(&buf, "%d\n", i+1)
+			}
+			for _, p := range m.Points {
+				fmt.Fprintf(&buf, "%s %s 0\n", f(p.X), f(p.Y))
+			}
+		} else {
+			fmt.Fprintf(&buf, "$Nodes\n0 0 0 0\n")
+		}
+		fmt.Fprintf(&buf, "$EndNodes\n")
+		// elements by blocks of consecutive elements with same
+		// entity and type for keep order of elements
+		type block struct {
+			entity
+			typ   int
+			begin int
+			end   int
+		}
+		var blocks []block
+		for i, el := range els {
+			e := entity{dim: mshDim(el.typ), tag: el.tag}
+			if last := len(blocks) - 1; 0 <= last &&
+				blocks[last].entity == e && blocks[last].typ == el.typ {
+				blocks[last].end = i + 1
+				continue
+			}
+			blocks = append(blocks, block{entity: e, typ: el.typ, begin: i, end: i + 1})
+		}
+		fmt.Fprintf(&buf, "$Elements\n%d %d %d %d\n",
+			len(blocks), len(els), 1, len(els))
+		for _, b := range blocks {
+			fmt.Fprintf(&buf, "%d %d %d %d\n", b.dim, index[b.entity], b.typ, b.end-b.begin)
+			for i := b.begin; i < b.end; i++ {
+				fmt.Fprintf(&buf, "%d", i+1)
+				for _, n := range els[i].nodes {
+					fmt.Fprintf(&buf, " %d", n+1)
+				}
+				fmt.Fprintf(&buf, "\n")
+			}
+		}
+		fmt.Fprintf(&buf, "$EndElements\n")
+
+	default:
+		return fmt.Errorf("not valid version of msh file: %d", version)
+	}
+
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// mshReader is reader of words from gmsh file
+type mshReader struct {
+	scanner *bufio.Scanner
+	word    string
+}
+
+// next return next word of gmsh file
+func (r *mshReader) next() bool {
+	if !r.scanner.Scan() {
+		return false
+	}
+	r.word = r.scanner.Text()
+	return true
+}
+
+// int return next integer value of gmsh file
+func (r *mshReader) int() (v int, err error) {
+	if !r.next() {
+		err = fmt.Errorf("unexpected end of file")
+		return
+	}
+	return strconv.Atoi(r.word)
+}
+
+// float return next float value of gmsh file
+func (r *mshReader) float() (v float64, err error) {
+	if !r.next() {
+		err = fmt.Errorf("unexpected end of file")
+		return
+	}
+	return strconv.ParseFloat(r.word, 64)
+}
+
+// ints return next integer values of gmsh file
+func (r *mshReader) ints(vs ...*int) (err error) {
+	for _, v := range vs {
+		if *v, err = r.int(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ReadMsh return model from gmsh ASCII file format version 2.2 or 4.1.
+// Supported elements are 2-node lines, 3-node second order lines as arcs,
+// 3-node triangles and 4-node quadrangles. Physical tags of elements are
+// tags of model.
+//
+// https://gmsh.info/doc/texinfo/gmsh.html#MSH-file-format
+func ReadMsh(r io.Reader) (m Model, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("ReadMsh")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	mr := mshReader{scanner: bufio.NewScanner(r)}
+	mr.scanner.Buffer(nil, 1024*1024)
+	mr.scanner.Split(bufio.ScanWords)
+
+	var (
+		version  string
+		nodes    = map[int]int{} // node tag to point index
+		physical = map[[2]int]int{}
+	)
+	// point return index of point by node tag
+	point := func(tag int) (index int, err error) {
+		index, ok := nodes[tag]
+		if !ok {
+			err = fmt.Errorf("node %d is not found", tag)
+		}
+		return
+	}
+	// add element into model
+	add := func(typ, tag int, ns []int) (err error) {
+		ps := make([]int, len(ns))
+		for i := range ns {
+			if ps[i], err = point(ns[i]); err != nil {
+				return
+			}
+		}
+		switch typ {
+		case mshLine:
+			m.Lines = append(m.Lines, [3]int{ps[0], ps[1], tag})
+		case mshArc:
+			m.Arcs = append(m.Arcs, [4]int{ps[0], ps[2], ps[1], tag})
+		case mshTriangle:
+			m.Triangles = append(m.Triangles, [4]int{ps[0], ps[1], ps[2], tag})
+		case mshQuadr:
+			m.Quadrs = append(m.Quadrs, [5]int{ps[0], ps[1], ps[2], ps[3], tag})
+		}
+		return
+	}
+	// size return amount of nodes for element type
+	size := func(typ int) (n int, err error) {
+		switch typ {
+		case mshPoint:
+			n = 1
+		case mshLine:
+			n = 2
+		case mshArc, mshTriangle:
+			n = 3
+		case mshQuadr:
+			n = 4
+		default:
+			err = fmt.Errorf("not supported type of element: %d", typ)
+		}
+		return
+	}
+
+	for mr.next() {
+		section := mr.word
+		if !strings.HasPrefix(section, "$") {
+			err = fmt.Errorf("not valid section name: %s", section)
+			return
+		}
+		switch section {
+		case "$MeshFormat":
+			if !mr.next() {
+				err = fmt.Errorf("version is not found")
+				return
+			}
+			version = mr.word
+			var fileType, dataSize int
+			if err = mr.ints(&fileType, &dataSize); err != nil {
+				return
+			}
+			if version != "2.2" && version != "4.1" {
+				err = fmt.Errorf("not supported version: %s", version)
+				return
+			}
+			if fileType != 0 {
+				err = fmt.Errorf("binary file is not supported")
+				return
+			}
+
+		case "$Entities":
+			if version != "4.1" {
+				err = fmt.Errorf("entities in version %s", version)
+				return
+			}
+			var amount [4]int
+			if err = mr.ints(&amount[0], &amount[1], &amount[2], &amount[3]); err != nil {
+				return
+			}
+			for d := range amount {
+				for i := 0; i < amount[d]; i++ {
+					var tag int
+					if tag, err = mr.int(); err != nil {
+						return
+					}
+					// coordinates
+					coords := 6
+					if d == 0 {
+						coords = 3
+					}
+					for c := 0; c < coords; c++ {
+						if _, err = mr.float(); err != nil {
+							return
+						}
+					}
+					// physical tags
+					var n int
+					if n, err = mr.int(); err != nil {
+						return
+					}
+					for p := 0; p < n; p++ {
+						var ph int
+						if ph, err = mr.int(); err != nil {
+							return
+						}
+						if p == 0 {
+							physical[[2]int{d, tag}] = ph
+						}
+					}
+					if d == 0 {
+						continue
+					}
+					// bounding entities
+					if n, err = mr.int(); err != nil {
+						return
+					}
+					for b := 0; b < n; b++ {
+						if _, err = mr.int(); err != nil {
+							return
+						}
+					}
+				}
+			}
+
+		case "$Nodes":
+			switch version {
+			case "2.2":
+				var n int
+				if n, err = mr.int(); err != nil {
+					return
+				}
+				for i := 0; i < n; i++ {
+					var tag int
+					var x, y float64
+					if tag, err = mr.int(); err != nil {
+						return
+					}
+					if x, err = mr.float(); err != nil {
+						return
+					}
+					if y, err = mr.float(); err != nil {
+						return
+					}
+					if _, err = mr.float(); err != nil {
+						return
+					}
+					nodes[tag] = len(m.Points)
+					m.Points = append(m.Points, Point{X: x, Y: y})
+				}
+			case "4.1":
+				var blocks, n, min, max int
+				if err = mr.ints(&blocks, &n, &min, &max); err != nil {
+					return
+				}
+				for b := 0; b < blocks; b++ {
+					var dim, tag, parametric, amount int
+					if err = mr.ints(&dim, &tag, &parametric, &amount); err != nil {
+						return
+					}
+					if parametric != 0 {
+						err = fmt.Errorf("parametric nodes are not supported")
+						return
+					}
+					tags := make([]int, amount)
+					for i := range tags {
+						if tags[i], err = mr.int(); err != nil {
+							return
+						}
+					}
+					for i := range tags {
+						var x, y float64
+						if x, err = mr.float(); err != nil {
+							return
+						}
+						if y, err = mr.float(); err != nil {
+							return
+						}
+						if _, err = mr.float(); err != nil {
+							return
+						}
+						nodes[tags[i]] = len(m.Points)
+						m.Points = append(m.Points, Point{X: x, Y: y})
+					}
+				}
+			default:
+				err = fmt.Errorf("nodes before mesh format")
+				return
+			}
+
+		case "$Elements":
+			switch version {
+			case "2.2":
+				var n int
+				if n, err = mr.int(); err != nil {
+					return
+				}
+				for i := 0; i < n; i++ {
+					var id, typ, ntags int
+					if err = mr.ints(&id, &typ, &ntags); err != nil {
+						return
+					}
+					tags := make([]int, ntags)
+					for t := range tags {
+						if tags[t], err = mr.int(); err != nil {
+							return
+						}
+					}
+					var amount int
+					if amount, err = size(typ); err != nil {
+						return
+					}
+					ns := make([]int, amount)
+					for k := range ns {
+						if ns[k], err = mr.int(); err != nil {
+							return
+						}
+					}
+					if typ == mshPoint {
+						continue
+					}
+					tag := 0
+					if 0 < len(tags) {
+						tag = tags[0]
+					}
+					if err = add(typ, tag, ns); err != nil {
+						return
+					}
+				}
+			case "4.1":
+				var blocks, n, min, max int
+				if err = mr.ints(&blocks, &n, &min, &max); err != nil {
+					return
+				}
+				for b := 0; b < blocks; b++ {
+					var dim, entity, typ, amount int
+					if err = mr.ints(&dim, &entity, &typ, &amount); err != nil {
+						return
+					}
+					var sz int
+					if sz, err = size(typ); err != nil {
+						return
+					}
+					tag, ok := physical[[2]int{dim, entity}]
+					if !ok {
+						tag = entity
+					}
+					for i := 0; i < amount; i++ {
+						// element tag
+						if _, err = mr.int(); err != nil {
+							return
+						}
+						ns := make([]int, sz)
+						for k := range ns {
+							if ns[k], err = mr.int(); err != nil {
+								return
+							}
+						}
+						if typ == mshPoint {
+							continue
+						}
+						if err = add(typ, tag, ns); err != nil {
+							return
+						}
+					}
+				}
+			default:
+				err = fmt.Errorf("elements before mesh format")
+				return
+			}
+
+		default:
+			// ignore section
+		}
+		// end of section
+		end := "$End" + strings.TrimPrefix(section, "$")
+		for {
+			if !mr.next() {
+				err = fmt.Errorf("end of section %s is not found", section)
+				return
+			}
+			if mr.word == end {
+				break
+			}
+		}
+	}
+	if err = mr.scanner.Err(); err != nil {
+		return
+	}
+	if version == "" {
+		err = fmt.Errorf("mesh format is not found")
+		return
+	}
+	return
+}
@@ -0,0 +1,152 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+// modelSection return model from section of file with models in format
+// of `Model.String`
+func modelSection(t *testing.T, filename, section string) (m Model) {
+	t.Helper()
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(b), "\n")
+	begin := -1
+	for i, line := range lines {
+		if line == section {
+			begin = i + 1
+		}
+	}
+	if begin < 0 {
+		t.Fatalf("section `%s` is not found in %s", section, filename)
+	}
+	var kind string
+	for _, line := range lines[begin:] {
+		switch line {
+		case "Points:", "Lines:", "Arcs:", "Triangles:", "Quadrs:":
+			kind = line
+			continue
+		}
+		fields := strings.Fields(strings.NewReplacer(
+			"{", " ", "}", " ", "[", " ", "]", " ").Replace(line))
+		if len(fields) < 2 {
+			break
+		}
+		if kind == "Points:" {
+			var p Point
+			if _, err = fmt.Sscan(fields[1]+" "+fields[2], &p.X, &p.Y); err != nil {
+				t.Fatalf("%s: %v", line, err)
+			}
+			m.Points = append(m.Points, p)
+			continue
+		}
+		var vs []int
+		for _, f := range fields[1:] {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				t.Fatalf("%s: %v", line, err)
+			}
+			vs = append(vs, v)
+		}
+		switch kind {
+		case "Lines:":
+			m.Lines = append(m.Lines, [3]int(vs))
+		case "Arcs:":
+			m.Arcs = append(m.Arcs, [4]int(vs))
+		case "Triangles:":
+			m.Triangles = append(m.Triangles, [4]int(vs))
+		case "Quadrs:":
+			m.Quadrs = append(m.Quadrs, [5]int(vs))
+		}
+	}
+	return
+}
+
+func TestMsh(t *testing.T) {
+	m := modelSection(t, filepath.Join("testdata", "test.model"), "After combine:")
+	if len(m.Points) == 0 || len(m.Quadrs) == 0 {
+		t.Fatalf("not valid model:\n%s", m)
+	}
+	// add arc
+	m.AddArc(Point{2, 0}, Point{3, 1}, Point{4, 0}, 4)
+
+	for _, version := range []MshVersion{Msh22, Msh41} {
+		t.Run(fmt.Sprintf("%d", version), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := m.WriteMsh(&buf, version); err != nil {
+				t.Fatal(err)
+			}
+			r, err := ReadMsh(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.String() != m.String() {
+				t.Errorf("not same models:\n%s\n%s", r, m)
+			}
+		})
+	}
+}
+
+func TestWriteMsh(t *testing.T) {
+	var m Model
+	m.AddArc(Point{2, 0}, Point{3, 1}, Point{4, 0}, 2)
+	m.AddLine(Point{-1, 0}, Point{1, 0}, 3)
+	m.AddTriangle(Point{5, 0}, Point{6, 0}, Point{5, 1}, 4)
+	m.Quadrs = append(m.Quadrs, [5]int{
+		m.AddPoint(Point{7, 0}),
+		m.AddPoint(Point{8, 0}),
+		m.AddPoint(Point{8, 1}),
+		m.AddPoint(Point{7, 1}),
+		5,
+	})
+	for _, version := range []MshVersion{Msh22, Msh41} {
+		var buf bytes.Buffer
+		if err := m.WriteMsh(&buf, version); err != nil {
+			t.Fatal(err)
+		}
+		compare.Test(t, filepath.Join("testdata", fmt.Sprintf("WriteMsh%d", version)), buf.Bytes())
+	}
+	if err := m.WriteMsh(&bytes.Buffer{}, MshVersion(-1)); err == nil {
+		t.Errorf("error for not valid version is not found")
+	}
+	// gmsh physical tags are positive
+	for _, tag := range []int{0, -1} {
+		c := m.Copy()
+		c.Lines[0][2] = tag
+		if err := c.WriteMsh(&bytes.Buffer{}, Msh41); err == nil {
+			t.Errorf("error for tag %d is not found", tag)
+		}
+	}
+}
+
+func TestReadMshError(t *testing.T) {
+	for i, msh := range []string{
+		"",
+		"MeshFormat",
+		"$MeshFormat\n3.0 0 8\n$EndMeshFormat",
+		"$MeshFormat\n2.2 1 8\n$EndMeshFormat",
+		"$MeshFormat\n2.2 0 8\n$EndMeshFormat\n$Nodes\n1\n1 0 0",
+		"$MeshFormat\n2.2 0 8\n$EndMeshFormat\n$Nodes\n1\n1 0 0 0\n$EndNodes\n" +
+			"$Elements\n1\n1 1 2 1 1 1 2\n$EndElements",
+		"$MeshFormat\n2.2 0 8\n$EndMeshFormat\n$Nodes\n1\n1 0 0 0\n$EndNodes\n" +
+			"$Elements\n1\n1 9 2 1 1 1 1 1 1 1 1\n$EndElements",
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			_, err := ReadMsh(strings.NewReader(msh))
+			if err == nil {
+				t.Fatalf("error is not found")
+			}
+			t.Logf("%v", err)
+		})
+	}
+}
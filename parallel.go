@@ -0,0 +1,40 @@
+package gog
+
+import "sync"
+
+// parallel run function for all indexes from 0 to size by workers.
+// Indexes are split on continuous parts for each worker. For one worker
+// function is run in caller goroutine. Panic in function is repeated in
+// caller goroutine.
+func parallel(workers, size int, f func(i int)) {
+	if workers <= 1 || size <= 1 {
+		for i := 0; i < size; i++ {
+			f(i)
+		}
+		return
+	}
+	workers = min(workers, size)
+	var (
+		wg     sync.WaitGroup
+		panics = make([]any, workers)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			defer func() {
+				panics[w] = recover()
+			}()
+			for i := w * size / workers; i < (w+1)*size/workers; i++ {
+				f(i)
+			}
+		}(w)
+	}
+	wg.Wait()
+	// first panic by order of indexes
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package gog
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	sum := make([]int, 1000)
+	parallel(7, len(sum), func(i int) {
+		sum[i] += i
+	})
+	for i := range sum {
+		if sum[i] != i {
+			t.Fatalf("not valid value of index %d: %d", i, sum[i])
+		}
+	}
+	// panic in caller goroutine
+	defer func() {
+		if r := recover(); r != "panic 500" {
+			t.Errorf("not valid panic: %v", r)
+		}
+	}()
+	parallel(4, len(sum), func(i int) {
+		if i == 500 || i == 900 {
+			panic(fmt.Sprintf("panic %d", i))
+		}
+	})
+}
+
+func TestParallelWorkers(t *testing.T) {
+	// results for one worker
+	model := func(workers int) Model {
+		m := randomSegments(1000)
+		m.SetConfig(Config{Workers: workers})
+		if err := m.Intersection(); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Split(0.02); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	mesh := func(workers int) Model {
+		m := randomModel(200)
+		m.SetConfig(Config{Workers: workers})
+		mesh, err := New(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = mesh.Split(0.05); err != nil {
+			t.Fatal(err)
+		}
+		if err = mesh.SplitSize(PointSize{
+			Sources: []PointSource{{Point: Point{0.5, 0.5}, Size: 0.01}},
+			Growth:  1.3,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err = mesh.Check(); err != nil {
+			t.Fatal(err)
+		}
+		return mesh.model
+	}
+	expModel := model(1).String()
+	expMesh := mesh(1).String()
+	for _, workers := range []int{2, 3, 8} {
+		t.Run(fmt.Sprintf("%d", workers), func(t *testing.T) {
+			if model(workers).String() != expModel {
+				t.Errorf("not same model")
+			}
+			if mesh(workers).String() != expMesh {
+				t.Errorf("not same mesh")
+			}
+		})
+	}
+}
+
+func BenchmarkParallel(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("Intersection/%d", workers), func(b *testing.B) {
+			model := randomSegments(5000)
+			model.SetConfig(Config{Workers: workers})
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				m := model.Copy()
+				if err := m.Intersection(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("SplitSize/%d", workers), func(b *testing.B) {
+			model := randomModel(200)
+			model.SetConfig(Config{Workers: workers})
+			for n := 0; n < b.N; n++ {
+				mesh, err := New(model)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err = mesh.SplitSize(ConstantSize(0.03)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package gog
+
+import "math"
+
+// indexMinPoints is minimal amount of points in model for using of
+// spatial hash. For small models linear search is faster.
+const indexMinPoints = 32
+
+// pointIndex is spatial hash of model points for fast search of same
+// points and bounding box of points. Index is rebuilded lazily, if slice
+// of points is changed not by Model methods: new slice or less amount
+// of points. Index is not rebuilded after direct change of coordinates
+// of points, so Model methods with such changes must call `resetIndex`.
+// Index is changed only by methods with pointer receiver, because
+// copies of Model have same index.
+type pointIndex struct {
+	size  float64              // size of cell
+	n     int                  // amount of indexed points
+	first *Point               // first point of indexed slice
+	cells map[[2]float64][]int // indexes of points in cells
+	box   box                  // bounding box of indexed points
+}
+
+// key return cell of point
+func (idx *pointIndex) key(p Point) [2]float64 {
+	return [2]float64{math.Floor(p.X / idx.size), math.Floor(p.Y / idx.size)}
+}
+
+// add point with index in cell
+func (idx *pointIndex) add(p Point, i int) {
+	k := idx.key(p)
+	idx.cells[k] = append(idx.cells[k], i)
+}
+
+// append point with next index
+func (idx *pointIndex) append(p Point) {
+	if idx.n == 0 {
+		idx.box = boxOf(p)
+	} else {
+		idx.box = idx.box.union(boxOf(p))
+	}
+	if idx.cells != nil {
+		idx.add(p, idx.n)
+	}
+	idx.n++
+}
+
+// remove point with index from cell
+func (idx *pointIndex) remove(p Point, i int) {
+	k := idx.key(p)
+	cell := idx.cells[k]
+	for j := range cell {
+		if cell[j] == i {
+			cell = append(cell[:j], cell[j+1:]...)
+			break
+		}
+	}
+	if len(cell) == 0 {
+		delete(idx.cells, k)
+		return
+	}
+	idx.cells[k] = cell
+}
+
+// points return index with actual bounding box of points.
+// If points are not exist, then return nil.
+func (m *Model) points() *pointIndex {
+	if len(m.Points) == 0 {
+		return nil
+	}
+	if m.index == nil {
+		m.index = new(pointIndex)
+	}
+	idx := m.index
+	if len(m.Points) < idx.n || (idx.first != nil && idx.first != &m.Points[0]) {
+		*idx = pointIndex{}
+	}
+	idx.first = &m.Points[0]
+	for idx.n < len(m.Points) {
+		idx.append(m.Points[idx.n])
+	}
+	return idx
+}
+
+// bounds return bounding box of points without changing of index.
+// If points are not exist, then return false.
+func (m *Model) bounds() (b box, ok bool) {
+	if len(m.Points) == 0 {
+		return
+	}
+	if idx := m.index; idx != nil && idx.n == len(m.Points) &&
+		idx.first == &m.Points[0] {
+		return idx.box, true
+	}
+	return boxOf(m.Points...), true
+}
+
+// pointIndex return actual spatial hash of points.
+// For small models return nil.
+func (m *Model) pointIndex() *pointIndex {
+	if len(m.Points) < indexMinPoints {
+		return nil
+	}
+	idx := m.points()
+	// size of cell must be more tolerance for checking only near cells
+	size := math.Max(1e-6, 4*m.tolerance().eps())
+	if idx.cells == nil || idx.size != size {
+		idx.size = size
+		idx.cells = make(map[[2]float64][]int, len(m.Points))
+		for i := 0; i < idx.n; i++ {
+			idx.add(m.Points[i], i)
+		}
+	}
+	return idx
+}
+
+// resetIndex remove spatial hash of points after changing of points
+func (m *Model) resetIndex() {
+	m.index = nil
+}
+
+// setPoint change coordinates of point with index i
+func (m *Model) setPoint(i int, p Point) {
+	if idx := m.index; idx != nil && i < idx.n &&
+		0 < len(m.Points) && idx.first == &m.Points[0] {
+		if idx.cells != nil {
+			idx.remove(m.Points[i], i)
+			idx.add(p, i)
+		}
+		idx.box = idx.box.union(boxOf(p))
+	}
+	m.Points[i] = p
+}
+
+// searchPoint return index of same point with minimal index.
+// Exactly same point have priority. If point is not found,
+// then return -1.
+func (m *Model) searchPoint(p Point) int {
+	tol := m.tolerance()
+	idx := m.pointIndex()
+	if idx == nil {
+		for i := range m.Points {
+			if p.X == m.Points[i].X && p.Y == m.Points[i].Y {
+				return i
+			}
+		}
+		for i := range m.Points {
+			if tol.SamePoints(p, m.Points[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+	found := -1
+	k := idx.key(p)
+	for _, i := range idx.cells[k] {
+		if p.X == m.Points[i].X && p.Y == m.Points[i].Y && (found < 0 || i < found) {
+			found = i
+		}
+	}
+	if 0 <= found {
+		return found
+	}
+	for dx := -1.0; dx <= 1; dx++ {
+		for dy := -1.0; dy <= 1; dy++ {
+			for _, i := range idx.cells[[2]float64{k[0] + dx, k[1] + dy}] {
+				if tol.SamePoints(p, m.Points[i]) && (found < 0 || i < found) {
+					found = i
+				}
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,137 @@
+package gog
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestPointIndex(t *testing.T) {
+	// linear search of point as reference
+	linear := func(ps []Point, p Point) int {
+		for i := range ps {
+			if p.X == ps[i].X && p.Y == ps[i].Y {
+				return i
+			}
+		}
+		for i := range ps {
+			if SamePoints(p, ps[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+	r := rand.New(rand.NewSource(0))
+	var m Model
+	check := func(p Point) {
+		t.Helper()
+		q := p
+		if math.Abs(q.X) < Eps {
+			q.X = 0
+		}
+		if math.Abs(q.Y) < Eps {
+			q.Y = 0
+		}
+		expect := linear(m.Points, q)
+		if expect < 0 {
+			expect = len(m.Points)
+		}
+		if index := m.AddPoint(p); index != expect {
+			t.Fatalf("not valid index for point %.12e: %d != %d", p, index, expect)
+		}
+	}
+	for i := 0; i < 2000; i++ {
+		p := Point{X: float64(r.Intn(100)) / 10, Y: float64(r.Intn(100)) / 10}
+		switch r.Intn(4) {
+		case 0:
+			// near to cell border
+			p.X += 0.5e-6 * float64(r.Intn(3)-1)
+		case 1:
+			// inside tolerance
+			p.X += Eps / 3 * float64(r.Intn(3)-1)
+			p.Y += Eps / 3 * float64(r.Intn(3)-1)
+		case 2:
+			// near to tolerance
+			p.Y += Eps * 1.01
+		}
+		check(p)
+		switch i {
+		case 500:
+			m.Move(0.1, 0.2)
+		case 1000:
+			m.Rotate(1, 2, 0.3)
+		case 1500:
+			m.RemovePoint(func(p Point) bool { return p.X < 5 })
+		}
+	}
+	if m.index == nil || m.index.cells == nil {
+		t.Fatalf("spatial hash is not used")
+	}
+	// new slice of points
+	m.Points = []Point{{0.1, 0.2}}
+	check(Point{0.1, 0.2})
+	for i := 0; i < 100; i++ {
+		check(Point{X: float64(i), Y: 0})
+	}
+	check(Point{0.1, 0.2})
+	// changing of point coordinates
+	for i := range m.Points {
+		m.setPoint(i, Point{X: m.Points[i].X, Y: 1})
+	}
+	check(Point{5, 1})
+	check(Point{5, 0})
+}
+
+func TestPointIndexShared(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	var m Model
+	m.SetConfig(Config{Tolerance: Tolerance{Relative: true}})
+	for i := 0; i < 100; i++ {
+		m.AddPoint(Point{X: r.Float64(), Y: r.Float64()})
+	}
+	if m.index == nil {
+		t.Fatalf("spatial hash is not used")
+	}
+	n := m.index.n
+	// copy have own spatial hash
+	c := m.Copy()
+	if c.index != nil {
+		t.Fatalf("spatial hash is shared with copy")
+	}
+	c.AddPoint(Point{X: 2, Y: 2})
+	if m.index.n != n {
+		t.Fatalf("spatial hash of model is changed by copy: %d != %d", m.index.n, n)
+	}
+	// methods with value receiver do not change spatial hash, but
+	// struct copy of model have same spatial hash
+	v := m
+	v.Points = c.Points
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = v.Validate()
+			_ = v.Regions()
+		}()
+	}
+	wg.Wait()
+	if m.index.n != n || m.index.first != &m.Points[0] {
+		t.Fatalf("spatial hash is changed by methods with value receiver")
+	}
+}
+
+func BenchmarkAddPoint(b *testing.B) {
+	ps := make([]Point, 20000)
+	r := rand.New(rand.NewSource(0))
+	for i := range ps {
+		ps[i] = Point{X: r.Float64(), Y: r.Float64()}
+	}
+	for n := 0; n < b.N; n++ {
+		var m Model
+		for i := range ps {
+			m.AddPoint(ps[i])
+		}
+	}
+}
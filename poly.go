@@ -0,0 +1,320 @@
+package gog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// Files formats of program Triangle by Jonathan Richard Shewchuk:
+//
+//	.node - points
+//	.ele  - triangles
+//	.poly - planar straight line graph with points, segments and holes
+//
+// https://www.cs.cmu.edu/~quake/triangle.html
+
+// polyReader is reader of lines with numbers from Triangle files.
+// Comments after symbol `#` and empty lines are ignored.
+type polyReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// next return numbers of next not empty line
+func (r *polyReader) next() (fields []string, err error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := r.scanner.Text()
+		if index := strings.Index(text, "#"); 0 <= index {
+			text = text[:index]
+		}
+		fields = strings.Fields(text)
+		if 0 < len(fields) {
+			return
+		}
+	}
+	if err = r.scanner.Err(); err != nil {
+		return
+	}
+	err = fmt.Errorf("unexpected end of file")
+	return
+}
+
+// ints return integer values of next line. Amount of values in line
+// must be not less minimal amount.
+func (r *polyReader) ints(min int) (vs []int, err error) {
+	fields, err := r.next()
+	if err != nil {
+		return
+	}
+	if len(fields) < min {
+		err = fmt.Errorf("line %d: expected %d values, but found %d",
+			r.line, min, len(fields))
+		return
+	}
+	vs = make([]int, len(fields))
+	for i := range fields {
+		if vs[i], err = strconv.Atoi(fields[i]); err != nil {
+			err = fmt.Errorf("line %d: %v", r.line, err)
+			return
+		}
+	}
+	return
+}
+
+// floats return float values of next line. Amount of values in line
+// must be not less minimal amount.
+func (r *polyReader) floats(min int) (vs []float64, err error) {
+	fields, err := r.next()
+	if err != nil {
+		return
+	}
+	if len(fields) < min {
+		err = fmt.Errorf("line %d: expected %d values, but found %d",
+			r.line, min, len(fields))
+		return
+	}
+	vs = make([]float64, len(fields))
+	for i := range fields {
+		if vs[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			err = fmt.Errorf("line %d: %v", r.line, err)
+			return
+		}
+	}
+	return
+}
+
+// nodes read points of node file. Key of map is vertex number and
+// value is index of point in model.
+func (r *polyReader) nodes(m *Model) (index map[int]int, err error) {
+	header, err := r.ints(2)
+	if err != nil {
+		return
+	}
+	if header[1] != 2 && header[0] != 0 {
+		err = fmt.Errorf("line %d: dimension is not 2", r.line)
+		return
+	}
+	index = map[int]int{}
+	for i := 0; i < header[0]; i++ {
+		var vs []float64
+		if vs, err = r.floats(3); err != nil {
+			return
+		}
+		number := int(vs[0])
+		if _, ok := index[number]; ok {
+			err = fmt.Errorf("line %d: duplicate vertex %d", r.line, number)
+			return
+		}
+		index[number] = len(m.Points)
+		m.Points = append(m.Points, Point{X: vs[1], Y: vs[2]})
+	}
+	return
+}
+
+// ReadNode return model with points from Triangle file format `.node`
+func ReadNode(node io.Reader) (m Model, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("ReadNode")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	r := polyReader{scanner: bufio.NewScanner(node)}
+	_, err = r.nodes(&m)
+	return
+}
+
+// ReadEle return model with points from Triangle file format `.node` and
+// triangles from file format `.ele`. First attribute of triangle is tag.
+// If triangle have not attributes, then tag is zero.
+// For 6-node triangles only corners are used.
+func ReadEle(node, ele io.Reader) (m Model, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("ReadEle")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	r := polyReader{scanner: bufio.NewScanner(node)}
+	index, err := r.nodes(&m)
+	if err != nil {
+		return
+	}
+	r = polyReader{scanner: bufio.NewScanner(ele)}
+	header, err := r.ints(2)
+	if err != nil {
+		return
+	}
+	if header[1] != 3 && header[1] != 6 {
+		err = fmt.Errorf("line %d: not valid amount of nodes per triangle: %d",
+			r.line, header[1])
+		return
+	}
+	for i := 0; i < header[0]; i++ {
+		var vs []float64
+		if vs, err = r.floats(1 + header[1]); err != nil {
+			return
+		}
+		var tr [4]int
+		for k := 0; k < 3; k++ {
+			var ok bool
+			if tr[k], ok = index[int(vs[1+k])]; !ok {
+				err = fmt.Errorf("line %d: vertex %d is not found", r.line, int(vs[1+k]))
+				return
+			}
+		}
+		if 1+header[1] < len(vs) {
+			tr[3] = int(math.Round(vs[1+header[1]]))
+		}
+		m.Triangles = append(m.Triangles, tr)
+	}
+	return
+}
+
+// ReadPoly return model and holes from Triangle file format `.poly`.
+// Segments are lines of model with boundary markers as tags. If file
+// `.poly` have not vertices, then points are read from file `.node`,
+// otherwise file `.node` is not used and may be nil.
+// Regional attributes are ignored.
+func ReadPoly(poly, node io.Reader) (m Model, holes []Point, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("ReadPoly")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	r := polyReader{scanner: bufio.NewScanner(poly)}
+	index, err := r.nodes(&m)
+	if err != nil {
+		return
+	}
+	if len(m.Points) == 0 {
+		if node == nil {
+			err = fmt.Errorf("vertices are not found")
+			return
+		}
+		nr := polyReader{scanner: bufio.NewScanner(node)}
+		if index, err = nr.nodes(&m); err != nil {
+			return
+		}
+	}
+	// segments
+	header, err := r.ints(1)
+	if err != nil {
+		return
+	}
+	markers := 1 < len(header) && header[1] == 1
+	for i := 0; i < header[0]; i++ {
+		var vs []int
+		if vs, err = r.ints(3); err != nil {
+			return
+		}
+		var line [3]int
+		for k := 0; k < 2; k++ {
+			var ok bool
+			if line[k], ok = index[vs[1+k]]; !ok {
+				err = fmt.Errorf("line %d: vertex %d is not found", r.line, vs[1+k])
+				return
+			}
+		}
+		if markers && 3 < len(vs) {
+			line[2] = vs[3]
+		}
+		m.Lines = append(m.Lines, line)
+	}
+	// holes
+	header, err = r.ints(1)
+	if err != nil {
+		return
+	}
+	for i := 0; i < header[0]; i++ {
+		var vs []float64
+		if vs, err = r.floats(3); err != nil {
+			return
+		}
+		holes = append(holes, Point{X: vs[1], Y: vs[2]})
+	}
+	return
+}
+
+// polyFloat return float value in Triangle format
+func polyFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// writeNodes write points in Triangle format with numbering from 1
+func (m Model) writeNodes(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "%d 2 0 0\n", len(m.Points))
+	for i, p := range m.Points {
+		fmt.Fprintf(buf, "%d %s %s\n", i+1, polyFloat(p.X), polyFloat(p.Y))
+	}
+}
+
+// WriteNode write points of model in Triangle file format `.node`
+func (m Model) WriteNode(w io.Writer) (err error) {
+	var buf bytes.Buffer
+	m.writeNodes(&buf)
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// WriteEle write triangles of model in Triangle file format `.ele`.
+// Tag of triangle is written as attribute.
+func (m Model) WriteEle(w io.Writer) (err error) {
+	var buf bytes.Buffer
+	var trs [][4]int
+	for _, tr := range m.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		trs = append(trs, tr)
+	}
+	fmt.Fprintf(&buf, "%d 3 1\n", len(trs))
+	for i, tr := range trs {
+		fmt.Fprintf(&buf, "%d %d %d %d %d\n", i+1, tr[0]+1, tr[1]+1, tr[2]+1, tr[3])
+	}
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// WritePoly write points, lines and holes of model in Triangle file
+// format `.poly`. Tags of lines are written as boundary markers.
+// Arcs are not supported, so arcs must be converted by `ArcsToLines`.
+func (m Model) WritePoly(w io.Writer, holes []Point) (err error) {
+	for _, a := range m.Arcs {
+		if a[0] == Removed || a[3] == Removed {
+			continue
+		}
+		return fmt.Errorf("arcs are not supported in poly file")
+	}
+	var buf bytes.Buffer
+	m.writeNodes(&buf)
+	var lines [][3]int
+	for _, l := range m.Lines {
+		if l[0] == Removed || l[2] == Removed {
+			continue
+		}
+		lines = append(lines, l)
+	}
+	fmt.Fprintf(&buf, "%d 1\n", len(lines))
+	for i, l := range lines {
+		fmt.Fprintf(&buf, "%d %d %d %d\n", i+1, l[0]+1, l[1]+1, l[2])
+	}
+	fmt.Fprintf(&buf, "%d\n", len(holes))
+	for i, h := range holes {
+		fmt.Fprintf(&buf, "%d %s %s\n", i+1, polyFloat(h.X), polyFloat(h.Y))
+	}
+	_, err = buf.WriteTo(w)
+	return
+}
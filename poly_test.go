@@ -0,0 +1,143 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestReadPoly(t *testing.T) {
+	// square with square hole in format of program Triangle
+	poly := `# vertices with numbering from zero
+8 2 0 1
+0 0.0 0.0 1
+1 3.0 0.0 1
+2 3.0 3.0 1
+3 0.0 3.0 1
+4 1.0 1.0 2
+5 2.0 1.0 2
+6 2.0 2.0 2
+7 1.0 2.0 2
+
+# segments
+8 1
+0 0 1 1
+1 1 2 1
+2 2 3 1
+3 3 0 1
+4 4 5 2
+5 5 6 2
+6 6 7 2
+7 7 4 2
+# holes
+1
+0 1.5 1.5
+`
+	m, holes, err := ReadPoly(strings.NewReader(poly), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s", m)
+	fmt.Fprintf(&buf, "Holes:\n%v\n", holes)
+	compare.Test(t, filepath.Join("testdata", "ReadPoly"), buf.Bytes())
+
+	// round trip
+	buf.Reset()
+	if err := m.WritePoly(&buf, holes); err != nil {
+		t.Fatal(err)
+	}
+	compare.Test(t, filepath.Join("testdata", "WritePoly"), buf.Bytes())
+	r, rholes, err := ReadPoly(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != m.String() || fmt.Sprint(rholes) != fmt.Sprint(holes) {
+		t.Errorf("not same models:\n%s\n%s", r, m)
+	}
+
+	// vertices in node file
+	var node bytes.Buffer
+	if err := m.WriteNode(&node); err != nil {
+		t.Fatal(err)
+	}
+	r, _, err = ReadPoly(strings.NewReader("0 2 0 0\n1 1\n1 1 2 7\n0\n"), &node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Points) != len(m.Points) || len(r.Lines) != 1 || r.Lines[0] != [3]int{0, 1, 7} {
+		t.Errorf("not valid model:\n%s", r)
+	}
+}
+
+func TestReadEle(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+	m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+	m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+	mesh, err := New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Split(0.5); err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Materials(); err != nil {
+		t.Fatal(err)
+	}
+	m.Get(mesh)
+	m.Lines = nil
+
+	var node, ele bytes.Buffer
+	if err := m.WriteNode(&node); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteEle(&ele); err != nil {
+		t.Fatal(err)
+	}
+	r, err := ReadEle(&node, &ele)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != m.String() {
+		t.Errorf("not same models:\n%s\n%s", r, m)
+	}
+}
+
+func TestReadPolyError(t *testing.T) {
+	for i, poly := range []string{
+		"",
+		"1 3 0 0\n",
+		"1 2 0 0\n1 0.0\n",
+		"1 2 0 0\n1 0.0 wrong\n",
+		"2 2 0 0\n1 0.0 0.0\n1 1.0 0.0\n",
+		"2 2 0 0\n1 0.0 0.0\n2 1.0 0.0\n1 0\n1 1 3\n0\n",
+		"2 2 0 0\n1 0.0 0.0\n2 1.0 0.0\n1 0\n1 1 2\n",
+		"0 2 0 0\n",
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			_, _, err := ReadPoly(strings.NewReader(poly), nil)
+			if err == nil {
+				t.Fatalf("error is not found")
+			}
+			t.Logf("%v", err)
+		})
+	}
+	var m Model
+	m.AddArc(Point{0, 0}, Point{1, 1}, Point{2, 0}, 1)
+	if err := m.WritePoly(&bytes.Buffer{}, nil); err == nil {
+		t.Errorf("error for arcs is not found")
+	}
+	if _, err := ReadEle(strings.NewReader("1 2 0 0\n1 0 0\n"),
+		strings.NewReader("1 3 0\n1 1 1 2\n")); err == nil {
+		t.Errorf("error for not exist vertex is not found")
+	}
+	if _, err := ReadNode(strings.NewReader("1 2 0 0\n")); err == nil {
+		t.Errorf("error for node file is not found")
+	}
+}
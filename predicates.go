@@ -0,0 +1,254 @@
+package gog
+
+import "math"
+
+// Adaptive exact geometric predicates.
+//
+// Jonathan Richard Shewchuk
+// Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric Predicates
+// https://www.cs.cmu.edu/~quake/robust.html
+//
+// Result is calculated by float64 with error bound. If result is less
+// error bound, then result is calculated exactly by expansion arithmetic.
+// Expansion is slice of nonoverlapping float64 values in order of
+// increasing magnitude without zero values.
+
+const (
+	// machine epsilon of float64
+	epsilon = 1.0 / (1 << 53)
+	// error bound for Orient2D
+	ccwErrBoundA = (3.0 + 16.0*epsilon) * epsilon
+	// error bound for InCircle
+	iccErrBoundA = (10.0 + 96.0*epsilon) * epsilon
+)
+
+// twoSum return sum a+b as x + y exactly
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	y = (a - av) + (b - bv)
+	return
+}
+
+// twoProduct return product a*b as x + y exactly
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	y = math.FMA(a, b, -x)
+	return
+}
+
+// expansion return expansion of sum a+b
+func expansion(a, b float64) []float64 {
+	x, y := twoSum(a, b)
+	return growExpansion([]float64{y}, x)
+}
+
+// growExpansion return expansion of sum e+b
+func growExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, len(e)+1)
+	q := b
+	for _, v := range e {
+		var r float64
+		q, r = twoSum(q, v)
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// sumExpansion return expansion of sum e+f
+func sumExpansion(e, f []float64) []float64 {
+	h := e
+	for _, v := range f {
+		h = growExpansion(h, v)
+	}
+	return h
+}
+
+// negExpansion return expansion of -e
+func negExpansion(e []float64) []float64 {
+	h := make([]float64, len(e))
+	for i := range e {
+		h[i] = -e[i]
+	}
+	return h
+}
+
+// scaleExpansion return expansion of product e*b
+func scaleExpansion(e []float64, b float64) []float64 {
+	h := make([]float64, 0, 2*len(e))
+	var q float64
+	for i, v := range e {
+		p1, p0 := twoProduct(v, b)
+		if i == 0 {
+			q = p1
+			if p0 != 0 {
+				h = append(h, p0)
+			}
+			continue
+		}
+		var r float64
+		var s float64
+		s, r = twoSum(q, p0)
+		if r != 0 {
+			h = append(h, r)
+		}
+		q, r = twoSum(p1, s)
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// mulExpansion return expansion of product e*f
+func mulExpansion(e, f []float64) []float64 {
+	h := []float64{0}
+	for _, v := range f {
+		h = sumExpansion(h, scaleExpansion(e, v))
+	}
+	return h
+}
+
+// estimate return approximate value of expansion with exact sign
+func estimate(e []float64) (v float64) {
+	for i := range e {
+		v += e[i]
+	}
+	return
+}
+
+// Orient2D return positive value if points a, b, c are in
+// counterclockwise order, negative value if points are in clockwise
+// order and zero if points are collinear. Sign of result is exact.
+func Orient2D(a, b, c Point) float64 {
+	var (
+		left  = (a.X - c.X) * (b.Y - c.Y)
+		right = (a.Y - c.Y) * (b.X - c.X)
+		det   = left - right
+		sum   float64
+	)
+	switch {
+	case 0 < left:
+		if right <= 0 {
+			return det
+		}
+		sum = left + right
+	case left < 0:
+		if 0 <= right {
+			return det
+		}
+		sum = -left - right
+	default:
+		return det
+	}
+	if bound := ccwErrBoundA * sum; bound <= det || bound <= -det {
+		return det
+	}
+	return orient2DExact(a, b, c)
+}
+
+// orient2DExact return result of Orient2D by expansion arithmetic
+func orient2DExact(a, b, c Point) float64 {
+	var (
+		acx = expansion(a.X, -c.X)
+		acy = expansion(a.Y, -c.Y)
+		bcx = expansion(b.X, -c.X)
+		bcy = expansion(b.Y, -c.Y)
+	)
+	return estimate(sumExpansion(
+		mulExpansion(acx, bcy),
+		negExpansion(mulExpansion(acy, bcx)),
+	))
+}
+
+// InCircle return positive value if point d is inside of circle through
+// points a, b, c in counterclockwise order, negative value if point is
+// outside and zero if all points are on circle. For points a, b, c in
+// clockwise order sign of result is opposite. Sign of result is exact.
+func InCircle(a, b, c, d Point) float64 {
+	var (
+		adx, ady = a.X - d.X, a.Y - d.Y
+		bdx, bdy = b.X - d.X, b.Y - d.Y
+		cdx, cdy = c.X - d.X, c.Y - d.Y
+
+		bdxcdy, cdxbdy = bdx * cdy, cdx * bdy
+		cdxady, adxcdy = cdx * ady, adx * cdy
+		adxbdy, bdxady = adx * bdy, bdx * ady
+
+		alift = adx*adx + ady*ady
+		blift = bdx*bdx + bdy*bdy
+		clift = cdx*cdx + cdy*cdy
+
+		det = alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+
+		permanent = (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+			(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+			(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	)
+	if bound := iccErrBoundA * permanent; bound < det || bound < -det {
+		return det
+	}
+	return inCircleExact(a, b, c, d)
+}
+
+// inCircleExact return result of InCircle by expansion arithmetic
+func inCircleExact(a, b, c, d Point) float64 {
+	var (
+		adx = expansion(a.X, -d.X)
+		ady = expansion(a.Y, -d.Y)
+		bdx = expansion(b.X, -d.X)
+		bdy = expansion(b.Y, -d.Y)
+		cdx = expansion(c.X, -d.X)
+		cdy = expansion(c.Y, -d.Y)
+	)
+	lift := func(x, y []float64) []float64 {
+		return sumExpansion(mulExpansion(x, x), mulExpansion(y, y))
+	}
+	cross := func(x1, y1, x2, y2 []float64) []float64 {
+		return sumExpansion(mulExpansion(x1, y2), negExpansion(mulExpansion(y1, x2)))
+	}
+	return estimate(sumExpansion(
+		sumExpansion(
+			mulExpansion(lift(adx, ady), cross(bdx, bdy, cdx, cdy)),
+			mulExpansion(lift(bdx, bdy), cross(cdx, cdy, adx, ady)),
+		),
+		mulExpansion(lift(cdx, cdy), cross(adx, ady, bdx, bdy)),
+	))
+}
+
+// orientation return orientation of points for triangulation.
+// For exact predicates Orient2D is used.
+func (mesh *Mesh) orientation(p1, p2, p3 Point) OrientationPoints {
+	if !mesh.model.exact() {
+		return mesh.model.tolerance().Orientation(p1, p2, p3)
+	}
+	switch v := Orient2D(p1, p2, p3); {
+	case v < 0:
+		return ClockwisePoints
+	case 0 < v:
+		return CounterClockwisePoints
+	}
+	return CollinearPoints
+}
+
+// inCircle return true only if point inside circle based on 3 circles
+// points for triangulation. For exact predicates InCircle is used.
+func (mesh *Mesh) inCircle(point Point, circle [3]Point) bool {
+	if !mesh.model.exact() {
+		return mesh.model.tolerance().PointInCircle(point, circle)
+	}
+	v := InCircle(circle[0], circle[1], circle[2], point)
+	if mesh.orientation(circle[0], circle[1], circle[2]) == ClockwisePoints {
+		v = -v
+	}
+	return 0 < v
+}
@@ -0,0 +1,274 @@
+package gog
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// sign return -1, 0, +1
+func sign(v float64) int {
+	switch {
+	case v < 0:
+		return -1
+	case 0 < v:
+		return 1
+	}
+	return 0
+}
+
+// orient2DRat return sign of Orient2D by rational arithmetic
+func orient2DRat(a, b, c Point) int {
+	r := func(v float64) *big.Rat { return new(big.Rat).SetFloat64(v) }
+	sub := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Sub(x, y) }
+	mul := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Mul(x, y) }
+	return sub(
+		mul(sub(r(a.X), r(c.X)), sub(r(b.Y), r(c.Y))),
+		mul(sub(r(a.Y), r(c.Y)), sub(r(b.X), r(c.X))),
+	).Sign()
+}
+
+// inCircleRat return sign of InCircle by rational arithmetic
+func inCircleRat(a, b, c, d Point) int {
+	r := func(v float64) *big.Rat { return new(big.Rat).SetFloat64(v) }
+	sub := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Sub(x, y) }
+	add := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Add(x, y) }
+	mul := func(x, y *big.Rat) *big.Rat { return new(big.Rat).Mul(x, y) }
+	var (
+		adx, ady = sub(r(a.X), r(d.X)), sub(r(a.Y), r(d.Y))
+		bdx, bdy = sub(r(b.X), r(d.X)), sub(r(b.Y), r(d.Y))
+		cdx, cdy = sub(r(c.X), r(d.X)), sub(r(c.Y), r(d.Y))
+	)
+	lift := func(x, y *big.Rat) *big.Rat { return add(mul(x, x), mul(y, y)) }
+	cross := func(x1, y1, x2, y2 *big.Rat) *big.Rat { return sub(mul(x1, y2), mul(y1, x2)) }
+	return add(add(
+		mul(lift(adx, ady), cross(bdx, bdy, cdx, cdy)),
+		mul(lift(bdx, bdy), cross(cdx, cdy, adx, ady))),
+		mul(lift(cdx, cdy), cross(adx, ady, bdx, bdy)),
+	).Sign()
+}
+
+func TestOrient2D(t *testing.T) {
+	check := func(a, b, c Point) {
+		t.Helper()
+		if s, e := sign(Orient2D(a, b, c)), orient2DRat(a, b, c); s != e {
+			t.Fatalf("not valid sign for %.20e %.20e %.20e: %d != %d", a, b, c, s, e)
+		}
+	}
+	// orientation of known points
+	if v := Orient2D(Point{0, 0}, Point{1, 0}, Point{0, 1}); v <= 0 {
+		t.Errorf("not counterclockwise: %e", v)
+	}
+	if v := Orient2D(Point{0, 0}, Point{0, 1}, Point{1, 0}); 0 <= v {
+		t.Errorf("not clockwise: %e", v)
+	}
+	// nearly collinear points on line y = x near point (0.5, 0.5)
+	ulp := math.Nextafter(0.5, 1) - 0.5
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 64; j++ {
+			p := Point{X: 0.5 + float64(i)*ulp, Y: 0.5 + float64(j)*ulp}
+			check(p, Point{12, 12}, Point{24, 24})
+			check(Point{12, 12}, p, Point{24, 24})
+		}
+	}
+	// nearly collinear random points
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 10000; i++ {
+		a := Point{X: r.Float64(), Y: r.Float64()}
+		b := Point{X: 100 * r.Float64(), Y: 100 * r.Float64()}
+		f := r.Float64()
+		c := Point{X: a.X + f*(b.X-a.X), Y: a.Y + f*(b.Y-a.Y)}
+		check(a, b, c)
+	}
+	// consistency with Orientation
+	var mesh Mesh
+	mesh.model.SetConfig(Config{ExactPredicates: true})
+	for _, ps := range [][3]Point{
+		{{0, 0}, {1, 0}, {0, 1}},
+		{{0, 0}, {0, 1}, {1, 0}},
+		{{0, 0}, {1, 1}, {2, 2}},
+	} {
+		if a, e := mesh.orientation(ps[0], ps[1], ps[2]), Orientation(ps[0], ps[1], ps[2]); a != e {
+			t.Errorf("not same orientation for %v: %v != %v", ps, a, e)
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	check := func(a, b, c, d Point) {
+		t.Helper()
+		if s, e := sign(InCircle(a, b, c, d)), inCircleRat(a, b, c, d); s != e {
+			t.Fatalf("not valid sign for %.20e %.20e %.20e %.20e: %d != %d",
+				a, b, c, d, s, e)
+		}
+	}
+	// points of circle in counterclockwise order
+	circle := [3]Point{{1, 0}, {0, 1}, {-1, 0}}
+	if v := InCircle(circle[0], circle[1], circle[2], Point{0, 0}); v <= 0 {
+		t.Errorf("point is not inside: %e", v)
+	}
+	if v := InCircle(circle[0], circle[1], circle[2], Point{2, 0}); 0 <= v {
+		t.Errorf("point is not outside: %e", v)
+	}
+	if v := InCircle(circle[2], circle[1], circle[0], Point{0, 0}); 0 <= v {
+		t.Errorf("not opposite sign for clockwise points: %e", v)
+	}
+	// cocircular points with integer coordinates
+	for _, radius := range []float64{5, 25, 65, 1105} {
+		var ps []Point
+		for x := 1.0; x < radius && len(ps) < 40; x++ {
+			y := math.Sqrt(radius*radius - x*x)
+			if y != math.Trunc(y) {
+				continue
+			}
+			ps = append(ps, Point{x, y}, Point{-y, x}, Point{-x, -y}, Point{y, -x})
+		}
+		for i := 3; i < len(ps); i++ {
+			if v := InCircle(ps[0], ps[1], ps[2], ps[i]); v != 0 {
+				t.Errorf("radius %e: point %v is not on circle: %e", radius, ps[i], v)
+			}
+			// move point by ulp
+			for _, d := range []Point{
+				{math.Nextafter(ps[i].X, math.Inf(1)), ps[i].Y},
+				{math.Nextafter(ps[i].X, math.Inf(-1)), ps[i].Y},
+				{ps[i].X, math.Nextafter(ps[i].Y, math.Inf(1))},
+				{ps[i].X, math.Nextafter(ps[i].Y, math.Inf(-1))},
+			} {
+				check(ps[0], ps[1], ps[2], d)
+			}
+		}
+	}
+	// cocircular grid points
+	ulp := math.Nextafter(0.1, 1) - 0.1
+	for i := 0; i < 32; i++ {
+		for j := 0; j < 32; j++ {
+			d := Point{X: 0.1 + float64(i-16)*ulp, Y: 0.3 + float64(j-16)*ulp}
+			check(Point{0.1, 0.1}, Point{0.3, 0.1}, Point{0.3, 0.3}, d)
+		}
+	}
+	// random nearly cocircular points
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 10000; i++ {
+		var ps [4]Point
+		x, y, radius := r.Float64(), r.Float64(), r.Float64()
+		for j := range ps {
+			angle := 2 * math.Pi * r.Float64()
+			ps[j] = Point{X: x + radius*math.Cos(angle), Y: y + radius*math.Sin(angle)}
+		}
+		check(ps[0], ps[1], ps[2], ps[3])
+	}
+}
+
+func TestExactPredicates(t *testing.T) {
+	models := []struct {
+		name  string
+		model func() Model
+	}{
+		{
+			name: "cocircular grid",
+			model: func() (m Model) {
+				const size = 20
+				m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+				m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+				m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+				m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+				for i := 0; i <= size; i++ {
+					for j := 0; j <= size; j++ {
+						m.AddPoint(Point{X: float64(i) / size, Y: float64(j) / size})
+					}
+				}
+				return
+			},
+		},
+		{
+			name: "cocircular points",
+			model: func() (m Model) {
+				const size = 64
+				for i := 0; i < size; i++ {
+					angle := 2 * math.Pi * float64(i) / size
+					m.AddPoint(Point{X: math.Cos(angle), Y: math.Sin(angle)})
+				}
+				m.AddPoint(Point{0, 0})
+				return
+			},
+		},
+		{
+			name: "nearly collinear points",
+			model: func() (m Model) {
+				m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+				m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+				m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+				m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+				r := rand.New(rand.NewSource(0))
+				for i := 0; i < 200; i++ {
+					x := 0.1 + 0.8*r.Float64()
+					m.AddPoint(Point{X: x, Y: 0.5 + 1e-6*math.Sin(100*x)})
+				}
+				return
+			},
+		},
+	}
+	for _, tc := range models {
+		t.Run(tc.name, func(t *testing.T) {
+			model := tc.model()
+			model.SetConfig(Config{ExactPredicates: true})
+			mesh, err := New(model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = mesh.Delanay(); err != nil {
+				t.Fatal(err)
+			}
+			if err = mesh.Check(); err != nil {
+				t.Fatal(err)
+			}
+			// Delaunay condition for all triangles
+			for i, tr := range mesh.model.Triangles {
+				if tr[0] == Removed {
+					continue
+				}
+				for _, n := range mesh.Triangles[i] {
+					if n < 0 {
+						continue
+					}
+					for _, p := range mesh.model.Triangles[n][:3] {
+						if p == tr[0] || p == tr[1] || p == tr[2] {
+							continue
+						}
+						// triangles are clockwise
+						v := InCircle(
+							mesh.model.Points[tr[0]],
+							mesh.model.Points[tr[1]],
+							mesh.model.Points[tr[2]],
+							mesh.model.Points[p],
+						)
+						if v < 0 && !fixedSide(mesh, tr, mesh.model.Triangles[n]) {
+							t.Fatalf("point %d is inside of circle triangle %d", p, i)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+// fixedSide return true if common side of triangles is fixed line
+func fixedSide(mesh *Mesh, a, b [4]int) bool {
+	var common []int
+	for _, p := range a[:3] {
+		if p == b[0] || p == b[1] || p == b[2] {
+			common = append(common, p)
+		}
+	}
+	for _, line := range mesh.model.Lines {
+		if line[2] != Fixed {
+			continue
+		}
+		if (line[0] == common[0] && line[1] == common[1]) ||
+			(line[0] == common[1] && line[1] == common[0]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,385 @@
+package gog
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// RefineOptions is options of quality refinement of mesh
+type RefineOptions struct {
+	// MinAngle is minimal angle of triangles in degree. If value is zero,
+	// then angles of triangles are not checked. Refinement is guaranteed
+	// for angles less or equal 20.7 degree and input angles between
+	// fixed lines more 60 degree. Maximal valid value is 34 degree.
+	MinAngle float64
+
+	// MaxArea is maximal area of triangles. If value is zero,
+	// then areas of triangles are not checked.
+	MaxArea float64
+
+	// Size is size field of triangles. Area of triangles is not more
+	// area of equilateral triangle with side by size field in center of
+	// triangle. If value is nil, then size field is not used.
+	Size SizeField
+
+	// MaxPoints is maximal amount of added points. If value is zero,
+	// then maximal amount is 100000 points.
+	MaxPoints int
+}
+
+// circumcenter return center of circle around triangle
+func circumcenter(a, b, c Point) (center Point, ok bool) {
+	d := 2 * (a.X*(b.Y-c.Y) + b.X*(c.Y-a.Y) + c.X*(a.Y-b.Y))
+	if d == 0 {
+		return
+	}
+	var (
+		a2 = a.X*a.X + a.Y*a.Y
+		b2 = b.X*b.X + b.Y*b.Y
+		c2 = c.X*c.X + c.Y*c.Y
+	)
+	center.X = (a2*(b.Y-c.Y) + b2*(c.Y-a.Y) + c2*(a.Y-b.Y)) / d
+	center.Y = (a2*(c.X-b.X) + b2*(a.X-c.X) + c2*(b.X-a.X)) / d
+	return center, true
+}
+
+// triangleAngles return angles of triangle in radians at each point
+func triangleAngles(ps [3]Point) (angles [3]float64) {
+	for i := 0; i < 3; i++ {
+		var (
+			o = ps[i]
+			a = ps[(i+1)%3]
+			b = ps[(i+2)%3]
+		)
+		angles[i] = math.Abs(math.Atan2(
+			(a.X-o.X)*(b.Y-o.Y)-(a.Y-o.Y)*(b.X-o.X),
+			(a.X-o.X)*(b.X-o.X)+(a.Y-o.Y)*(b.Y-o.Y),
+		))
+	}
+	return
+}
+
+// encroached return true if point is inside diametral circle of segment
+// with tolerance eps
+func encroached(p, a, b Point, eps float64) bool {
+	dot := (a.X-p.X)*(b.X-p.X) + (a.Y-p.Y)*(b.Y-p.Y)
+	d := (b.X-a.X)*(b.X-a.X) + (b.Y-a.Y)*(b.Y-a.Y)
+	return dot < -eps*d
+}
+
+// shellPoint return point on segment from apex to other point on distance
+// power of 2 nearest to middle of segment. Concentric shells prevent
+// infinite splitting of segments with small angle between them.
+func shellPoint(apex, other Point) Point {
+	l := Distance(apex, other)
+	f := math.Pow(2, math.Round(math.Log2(l/2))) / l
+	return Point{
+		X: apex.X + f*(other.X-apex.X),
+		Y: apex.Y + f*(other.Y-apex.Y),
+	}
+}
+
+// Refine is Ruppert refinement of triangulation. Segments are fixed
+// lines and boundary sides of triangulation. Encroached segments are
+// splitted in middle point or on concentric shells around points
+// before refinement, triangles with small angle or big area are
+// splitted by circumcenter. Fixed points are not moved.
+// Small angles between two segments are not refined.
+func (mesh *Mesh) Refine(opts RefineOptions) (err error) {
+	if mesh.model.log() {
+		log.Printf("Refine")
+	}
+	defer func() {
+		if err != nil {
+			et := eTree.New("Refine")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if opts.MinAngle < 0 || 34 < opts.MinAngle {
+		err = fmt.Errorf("minimal angle is not valid: %f", opts.MinAngle)
+		return
+	}
+	if opts.MaxArea < 0 {
+		err = fmt.Errorf("maximal area is not valid: %f", opts.MaxArea)
+		return
+	}
+	if opts.Size != nil {
+		if err = checkSize(opts.Size); err != nil {
+			return
+		}
+	}
+	if opts.MaxPoints <= 0 {
+		opts.MaxPoints = 100000
+	}
+	minAngle := opts.MinAngle * math.Pi / 180.0
+	eps := mesh.model.tolerance().eps()
+
+	// side of triangle is segment
+	segment := func(tr, side int) bool {
+		if mesh.Triangles[tr][side] == Boundary {
+			return true
+		}
+		t := mesh.model.Triangles[tr]
+		return 0 < len(mesh.linesBetween(t[side], t[(side+1)%3]))
+	}
+
+	// points before refinement
+	input := make([]bool, len(mesh.model.Points))
+	for i := range input {
+		input[i] = true
+	}
+	isInput := func(p int) bool {
+		return p < len(input) && input[p]
+	}
+	// split point of segment between points with indexes a, b
+	splitPoint := func(a, b int) Point {
+		pa, pb := mesh.model.Points[a], mesh.model.Points[b]
+		switch {
+		case isInput(a) && !isInput(b):
+			return shellPoint(pa, pb)
+		case !isInput(a) && isInput(b):
+			return shellPoint(pb, pa)
+		}
+		return MiddlePoint(pa, pb)
+	}
+
+	// queues of triangles for checking of encroached segments and
+	// quality of triangles
+	var segments, triangles []int
+	push := func(trs ...int) {
+		segments = append(segments, trs...)
+		triangles = append(triangles, trs...)
+	}
+	added := 0
+	// add point and return true if point is new
+	addPoint := func(p Point, tag, tr int) (isNew bool, err error) {
+		if opts.MaxPoints <= added {
+			return false, fmt.Errorf("too many added points: %d", added)
+		}
+		added++
+		var (
+			size = len(mesh.model.Points)
+			from = len(mesh.model.Triangles)
+			idp  int
+		)
+		if idp, err = mesh.AddPoint(p, tag, tr); err != nil {
+			return
+		}
+		if isNew = size < len(mesh.model.Points); isNew {
+			// new triangles with point are at the end
+			for i := len(mesh.model.Triangles) - 1; from <= i; i-- {
+				if trs := mesh.around(i, idp); 0 < len(trs) {
+					push(trs...)
+					break
+				}
+			}
+		}
+		return
+	}
+
+	// split encroached segment in triangle
+	splitSegments := func(i int) (split bool, err error) {
+		t := mesh.model.Triangles[i]
+		if t[0] == Removed {
+			return
+		}
+		for j := 0; j < 3; j++ {
+			if !segment(i, j) {
+				continue
+			}
+			var (
+				a = mesh.model.Points[t[j]]
+				b = mesh.model.Points[t[(j+1)%3]]
+				p = mesh.model.Points[t[(j+2)%3]]
+			)
+			if !encroached(p, a, b, eps) {
+				continue
+			}
+			return addPoint(splitPoint(t[j], t[(j+1)%3]), Fixed, i)
+		}
+		return
+	}
+
+	// encroachedBy return triangle and side of segment encroached by
+	// point. Segments are searched only in triangles near triangle `i`
+	// with point inside of circumcircle. Segments are not encroached
+	// before, so circumcircle of triangle near segment contains half
+	// of diametral circle of segment.
+	encroachedBy := func(i int, p Point) (tr, side int, found bool) {
+		visited := map[int]bool{i: true}
+		queue := []int{i}
+		for 0 < len(queue) {
+			k := queue[0]
+			queue = queue[1:]
+			t := mesh.model.Triangles[k]
+			for j := 0; j < 3; j++ {
+				if segment(k, j) {
+					var (
+						a = mesh.model.Points[t[j]]
+						b = mesh.model.Points[t[(j+1)%3]]
+					)
+					if encroached(p, a, b, eps) {
+						return k, j, true
+					}
+					continue
+				}
+				n := mesh.Triangles[k][j]
+				if visited[n] {
+					continue
+				}
+				visited[n] = true
+				nt := mesh.model.Triangles[n]
+				if mesh.inCircle(p, [3]Point{
+					mesh.model.Points[nt[0]],
+					mesh.model.Points[nt[1]],
+					mesh.model.Points[nt[2]],
+				}) {
+					queue = append(queue, n)
+				}
+			}
+		}
+		return
+	}
+
+	// triangle is not valid by quality
+	bad := func(i int) (_ bool, err error) {
+		t := mesh.model.Triangles[i]
+		ps := [3]Point{
+			mesh.model.Points[t[0]],
+			mesh.model.Points[t[1]],
+			mesh.model.Points[t[2]],
+		}
+		area := Area(ps[0], ps[1], ps[2])
+		if 0 < opts.MaxArea && opts.MaxArea < area {
+			return true, nil
+		}
+		if opts.Size != nil {
+			center := Point{
+				X: (ps[0].X + ps[1].X + ps[2].X) / 3.0,
+				Y: (ps[0].Y + ps[1].Y + ps[2].Y) / 3.0,
+			}
+			size := opts.Size.Size(center)
+			if !(0 < size) {
+				return false, fmt.Errorf("not valid size %f in point %.9f", size, center)
+			}
+			if math.Sqrt(3)/4*size*size < area {
+				return true, nil
+			}
+		}
+		if minAngle == 0 {
+			return false, nil
+		}
+		angles := triangleAngles(ps)
+		k := 0
+		for j := 1; j < 3; j++ {
+			if angles[j] < angles[k] {
+				k = j
+			}
+		}
+		if minAngle <= angles[k] {
+			return false, nil
+		}
+		// ignore small angle between segments
+		return !(segment(i, k) && segment(i, (k+2)%3)), nil
+	}
+
+	// triangles without possibility of refinement
+	skip := map[int]bool{}
+	// all triangles are checked without adding of points
+	checked := true
+
+	// refinement is based on Delaunay triangulation
+	if err = mesh.Delanay(); err != nil {
+		return
+	}
+
+	all := func() {
+		for i := range mesh.model.Triangles {
+			push(i)
+		}
+	}
+	all()
+	for {
+		// split all encroached segments
+		for 0 < len(segments) {
+			i := segments[len(segments)-1]
+			segments = segments[:len(segments)-1]
+			if _, err = splitSegments(i); err != nil {
+				return
+			}
+		}
+		if 0 < len(triangles) {
+			// split bad triangle
+			i := triangles[len(triangles)-1]
+			triangles = triangles[:len(triangles)-1]
+			t := mesh.model.Triangles[i]
+			if t[0] == Removed || skip[i] {
+				continue
+			}
+			var isBad bool
+			if isBad, err = bad(i); err != nil {
+				return
+			}
+			if !isBad {
+				continue
+			}
+			c, ok := circumcenter(
+				mesh.model.Points[t[0]],
+				mesh.model.Points[t[1]],
+				mesh.model.Points[t[2]],
+			)
+			if !ok {
+				skip[i] = true
+				continue
+			}
+			// circumcenter encroach segments
+			if k, j, found := encroachedBy(i, c); found {
+				tr := mesh.model.Triangles[k]
+				var split bool
+				if split, err = addPoint(splitPoint(tr[j], tr[(j+1)%3]), Fixed, k); err != nil {
+					return
+				}
+				if !split {
+					skip[i] = true
+					continue
+				}
+				checked = false
+				// triangle is checked again after splitting of
+				// encroached segments
+				triangles = append(triangles, i)
+				continue
+			}
+			tr := mesh.locate(c)
+			if tr < 0 {
+				skip[i] = true
+				continue
+			}
+			var isNew bool
+			if isNew, err = addPoint(c, Movable, tr); err != nil {
+				return
+			}
+			if !isNew {
+				skip[i] = true
+				continue
+			}
+			checked = false
+			continue
+		}
+		if checked {
+			break
+		}
+		// Delaunay flips after adding of point change triangles
+		// without that point, so check all triangles again
+		checked = true
+		skip = map[int]bool{}
+		if err = mesh.Delanay(); err != nil {
+			return
+		}
+		all()
+	}
+	return
+}
@@ -0,0 +1,156 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRefine(t *testing.T) {
+	// minimal angle of triangles in degree
+	minAngle := func(mesh *Mesh) (angle float64) {
+		angle = 180
+		for _, tr := range mesh.model.Triangles {
+			if tr[0] == Removed {
+				continue
+			}
+			for _, a := range triangleAngles([3]Point{
+				mesh.model.Points[tr[0]],
+				mesh.model.Points[tr[1]],
+				mesh.model.Points[tr[2]],
+			}) {
+				angle = math.Min(angle, a*180/math.Pi)
+			}
+		}
+		return
+	}
+	// maximal area of triangles
+	maxArea := func(mesh *Mesh) (area float64) {
+		for _, tr := range mesh.model.Triangles {
+			if tr[0] == Removed {
+				continue
+			}
+			area = math.Max(area, Area(
+				mesh.model.Points[tr[0]],
+				mesh.model.Points[tr[1]],
+				mesh.model.Points[tr[2]],
+			))
+		}
+		return
+	}
+	models := []struct {
+		model func() Model
+		// input angles between lines are more 60 degree
+		guaranteed bool
+	}{
+		{func() (m Model) {
+			m.AddLine(Point{0, 0}, Point{10, 0}, 1)
+			m.AddLine(Point{10, 0}, Point{10, 1}, 1)
+			m.AddLine(Point{10, 1}, Point{0, 1}, 1)
+			m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+			m.AddLine(Point{2, 0.5}, Point{8, 0.6}, 2)
+			return
+		}, true},
+		{func() (m Model) {
+			m.AddLine(Point{-2, -2}, Point{2, -2}, 1)
+			m.AddLine(Point{2, -2}, Point{2, 2}, 1)
+			m.AddLine(Point{2, 2}, Point{-2, 2}, 1)
+			m.AddLine(Point{-2, 2}, Point{-2, -2}, 1)
+			m.AddCircle(0, 0, 1, 2)
+			if err := m.Split(0.5); err != nil {
+				t.Fatal(err)
+			}
+			m.ArcsToLines()
+			return
+		}, true},
+		{func() (m Model) {
+			m.AddCircle(0, 0, 1, 1)
+			m.AddLine(Point{-1, 0}, Point{1, 0}, 2)
+			m.AddPoint(Point{0.1, 0.01})
+			if err := m.Intersection(); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}, false},
+	}
+	for i, c := range models {
+		for _, opts := range []RefineOptions{
+			{MinAngle: 20.7},
+			{MinAngle: 25, MaxArea: 0.05},
+			{MaxArea: 0.1},
+		} {
+			t.Run(fmt.Sprintf("%d%v", i, opts), func(t *testing.T) {
+				mesh, err := New(c.model())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = mesh.Materials(); err != nil {
+					t.Fatal(err)
+				}
+				before := minAngle(mesh)
+				if err = mesh.Refine(opts); err != nil {
+					t.Fatal(err)
+				}
+				if err = mesh.Check(); err != nil {
+					t.Fatal(err)
+				}
+				after := minAngle(mesh)
+				t.Logf("minimal angle: before %.2f, after %.2f. Maximal area: %.4f",
+					before, after, maxArea(mesh))
+				if c.guaranteed && after < opts.MinAngle-1e-6 {
+					t.Errorf("minimal angle %f is less %f", after, opts.MinAngle)
+				}
+				if 0 < opts.MaxArea && opts.MaxArea < maxArea(mesh) {
+					t.Errorf("maximal area %f is more %f", maxArea(mesh), opts.MaxArea)
+				}
+			})
+		}
+	}
+}
+
+func TestRefineError(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{0, 1}, 1)
+	for i, opts := range []RefineOptions{
+		{MinAngle: -1},
+		{MinAngle: 40},
+		{MaxArea: -1},
+		{MaxArea: 1e-6, MaxPoints: 10},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			mesh, err := New(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := mesh.Refine(opts); err == nil {
+				t.Fatalf("error is not found")
+			}
+		})
+	}
+}
+
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkRefine/0.04         	      32	  35522878 ns/op
+// BenchmarkRefine/0.01         	       7	 164763583 ns/op
+// BenchmarkRefine/0.0025       	       2	 743016955 ns/op
+func BenchmarkRefine(b *testing.B) {
+	for _, area := range []float64{0.04, 0.01, 0.0025} {
+		b.Run(fmt.Sprintf("%g", area), func(b *testing.B) {
+			var m Model
+			m.AddLine(Point{0, 0}, Point{10, 0}, 1)
+			m.AddLine(Point{10, 0}, Point{10, 10}, 1)
+			m.AddLine(Point{10, 10}, Point{0, 10}, 1)
+			m.AddLine(Point{0, 10}, Point{0, 0}, 1)
+			for n := 0; n < b.N; n++ {
+				mesh, err := New(m)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err = mesh.Refine(RefineOptions{MinAngle: 20, MaxArea: area}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
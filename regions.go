@@ -0,0 +1,461 @@
+package gog
+
+import (
+	"math"
+	"sort"
+)
+
+// Region is closed face of model lines and arcs
+type Region struct {
+	Outer     Loop    // outer boundary in counterclockwise order
+	Holes     []Loop  // boundaries of holes in clockwise order
+	Area      float64 // area of region without holes
+	Perimeter float64 // length of outer boundary and boundaries of holes
+	Interior  Point   // point inside of region
+}
+
+// Loop is closed boundary of lines and arcs
+type Loop struct {
+	// Points is indexes of loop points.
+	// Edge with index k is from point k to point k+1.
+	Points []int
+	Edges  []Edge
+}
+
+// Edge is line or arc of loop
+type Edge struct {
+	Arc     bool // true for arc, false for line
+	Index   int  // index of line or arc in model
+	Reverse bool // true, if direction of edge is opposite to element
+}
+
+// regionEdge is line or arc of planar graph
+type regionEdge struct {
+	Edge
+	from, to int // indexes of end points
+
+	// arc parameters
+	center       Point
+	radius       float64
+	angle, sweep float64 // start angle and signed sweep angle
+}
+
+// point return point of edge with parameter from 0 (start) to 1 (end)
+func (e regionEdge) point(s float64) Point {
+	a := e.angle + s*e.sweep
+	return Point{
+		X: e.center.X + e.radius*math.Cos(a),
+		Y: e.center.Y + e.radius*math.Sin(a),
+	}
+}
+
+// parameter return parameter of point on arc by angle of point.
+// Parameter is from 0 (start) to 1 (end) for points on arc.
+func (e regionEdge) parameter(a float64) float64 {
+	d := math.Mod(a-e.angle, 2*math.Pi)
+	if e.sweep < 0 {
+		d = -d
+	}
+	if d < 0 {
+		d += 2 * math.Pi
+	}
+	return d / math.Abs(e.sweep)
+}
+
+// extremes return sorted parameters of arc points with minimal and
+// maximal coordinate Y
+func (e regionEdge) extremes() (ss []float64) {
+	if e.radius == 0 {
+		return
+	}
+	for _, a := range []float64{math.Pi / 2, -math.Pi / 2} {
+		if s := e.parameter(a); 0 < s && s < 1 {
+			ss = append(ss, s)
+		}
+	}
+	sort.Float64s(ss)
+	return
+}
+
+// crossings return coordinates X of crossing edge by horizontal line.
+// Ends of edge are points `from` and `to`. Edge is crossed, if ends of
+// edge or ends of monotone parts of arc are on different sides of line,
+// where point on line is below of line.
+func (e regionEdge) crossings(from, to Point, y float64) (xs []float64) {
+	if e.radius == 0 {
+		if (from.Y > y) != (to.Y > y) {
+			xs = append(xs, from.X+(y-from.Y)*(to.X-from.X)/(to.Y-from.Y))
+		}
+		return
+	}
+	ss := append(append([]float64{0}, e.extremes()...), 1)
+	for k := 1; k < len(ss); k++ {
+		p0, p1 := e.point(ss[k-1]), e.point(ss[k])
+		if k == 1 {
+			p0 = from
+		}
+		if k == len(ss)-1 {
+			p1 = to
+		}
+		if (p0.Y > y) == (p1.Y > y) {
+			continue
+		}
+		// side of circle for monotone part of arc
+		dy := y - e.center.Y
+		dx := math.Sqrt(math.Max(0, e.radius*e.radius-dy*dy))
+		if math.Cos(e.angle+(ss[k-1]+ss[k])/2*e.sweep) < 0 {
+			dx = -dx
+		}
+		xs = append(xs, e.center.X+dx)
+	}
+	return
+}
+
+// regionLevels is maximal amount of horizontal lines for search of
+// point inside of region
+const regionLevels = 16
+
+// Regions return all closed faces of model lines and arcs with outer
+// boundary, holes, area, perimeter and point inside of region.
+// Model must be intersected before, see `Model.Intersection`.
+// Removed, zero length and not valid elements are ignored.
+// Open chains of lines and arcs are not part of regions.
+func (m Model) Regions() (regions []Region) {
+	tol := m.tolerance()
+	valid := func(ids ...int) bool {
+		for _, id := range ids {
+			if id < 0 || len(m.Points) <= id {
+				return false
+			}
+		}
+		return true
+	}
+	// edges of planar graph
+	var edges []regionEdge
+	for i, l := range m.Lines {
+		if !valid(l[0], l[1]) || l[0] == l[1] ||
+			tol.SamePoints(m.Points[l[0]], m.Points[l[1]]) {
+			continue
+		}
+		edges = append(edges, regionEdge{
+			Edge: Edge{Index: i},
+			from: l[0],
+			to:   l[1],
+		})
+	}
+	for i, a := range m.Arcs {
+		if !valid(a[0], a[1], a[2]) {
+			continue
+		}
+		p0, p1, p2 := m.Points[a[0]], m.Points[a[1]], m.Points[a[2]]
+		if tol.SamePoints(p0, p2) {
+			continue
+		}
+		e := regionEdge{
+			Edge: Edge{Arc: true, Index: i},
+			from: a[0],
+			to:   a[2],
+		}
+		if tol.SamePoints(p0, p1) || tol.SamePoints(p1, p2) ||
+			tol.Orientation(p0, p1, p2) == CollinearPoints {
+			// degenerate arc is line
+			edges = append(edges, e)
+			continue
+		}
+		xc, yc, r, err := tol.Arc(p0, p1, p2)
+		if err != nil {
+			// arc without circle is line
+			edges = append(edges, e)
+			continue
+		}
+		e.center = Point{X: xc, Y: yc}
+		e.radius = r
+		e.angle = math.Atan2(p0.Y-yc, p0.X-xc)
+		e.sweep = math.Mod(math.Atan2(p2.Y-yc, p2.X-xc)-e.angle, 2*math.Pi)
+		if tol.Orientation(p0, p1, p2) == CounterClockwisePoints {
+			if e.sweep < 0 {
+				e.sweep += 2 * math.Pi
+			}
+		} else if 0 < e.sweep {
+			e.sweep -= 2 * math.Pi
+		}
+		edges = append(edges, e)
+	}
+	if len(edges) == 0 {
+		return
+	}
+
+	// half-edge 2*k is edge k, half-edge 2*k+1 is reversed edge k
+	origin := func(h int) int {
+		if h%2 == 0 {
+			return edges[h/2].from
+		}
+		return edges[h/2].to
+	}
+	// direction angle of half-edge in origin point
+	direction := func(h int) float64 {
+		var (
+			e    = edges[h/2]
+			from = m.Points[e.from]
+			to   = m.Points[e.to]
+		)
+		if e.radius != 0 {
+			// point on arc near origin
+			from, to = e.point(0), e.point(1e-3)
+			if h%2 == 1 {
+				from, to = e.point(1), e.point(1-1e-3)
+			}
+		} else if h%2 == 1 {
+			from, to = to, from
+		}
+		return math.Atan2(to.Y-from.Y, to.X-from.X)
+	}
+	removed := make([]bool, len(edges))
+	var (
+		face  []int   // index of face for each half-edge
+		loops [][]int // half-edges of faces
+	)
+	for {
+		// sort half-edges around points in counterclockwise order
+		out := map[int][]int{}
+		for h := 0; h < 2*len(edges); h++ {
+			if removed[h/2] {
+				continue
+			}
+			out[origin(h)] = append(out[origin(h)], h)
+		}
+		position := make([]int, 2*len(edges))
+		for _, hs := range out {
+			type half struct {
+				angle float64
+				h     int
+			}
+			halfs := make([]half, len(hs))
+			for k, h := range hs {
+				halfs[k] = half{angle: direction(h), h: h}
+			}
+			sort.SliceStable(halfs, func(i, j int) bool {
+				return halfs[i].angle < halfs[j].angle
+			})
+			for k := range halfs {
+				hs[k] = halfs[k].h
+				position[hs[k]] = k
+			}
+		}
+		// next half-edge of face is next clockwise half-edge
+		// after reversed half-edge
+		next := func(h int) int {
+			t := h ^ 1
+			hs := out[origin(t)]
+			return hs[(position[t]-1+len(hs))%len(hs)]
+		}
+		face = make([]int, 2*len(edges))
+		for i := range face {
+			face[i] = -1
+		}
+		loops = loops[:0]
+		for h := range face {
+			if removed[h/2] || face[h] != -1 {
+				continue
+			}
+			var loop []int
+			for c := h; face[c] == -1; c = next(c) {
+				face[c] = len(loops)
+				loop = append(loop, c)
+			}
+			loops = append(loops, loop)
+		}
+		// remove open chains and bridges with same face on both sides
+		bridge := false
+		for k := range edges {
+			if !removed[k] && face[2*k] == face[2*k+1] {
+				removed[k] = true
+				bridge = true
+			}
+		}
+		if !bridge {
+			break
+		}
+	}
+
+	// parameters of loops
+	type loopData struct {
+		Loop
+		half      []int
+		area      float64 // signed area
+		perimeter float64
+		component int
+	}
+	data := make([]loopData, len(loops))
+	for f, loop := range loops {
+		d := &data[f]
+		d.half = loop
+		for _, h := range loop {
+			e := edges[h/2]
+			edge := e.Edge
+			edge.Reverse = h%2 == 1
+			d.Points = append(d.Points, origin(h))
+			d.Edges = append(d.Edges, edge)
+			from, to := m.Points[e.from], m.Points[e.to]
+			if edge.Reverse {
+				from, to = to, from
+			}
+			d.area += (from.X*to.Y - to.X*from.Y) / 2
+			if e.radius == 0 {
+				d.perimeter += Distance(from, to)
+				continue
+			}
+			angle := math.Abs(e.sweep)
+			d.perimeter += e.radius * angle
+			segment := e.radius * e.radius / 2 * (angle - math.Sin(angle))
+			if (0 < e.sweep) == edge.Reverse {
+				segment = -segment
+			}
+			d.area += segment
+		}
+	}
+	// components of planar graph
+	{
+		group := make([]int, len(m.Points))
+		for i := range group {
+			group[i] = i
+		}
+		var find func(i int) int
+		find = func(i int) int {
+			if group[i] != i {
+				group[i] = find(group[i])
+			}
+			return group[i]
+		}
+		for k, e := range edges {
+			if !removed[k] {
+				group[find(e.from)] = find(e.to)
+			}
+		}
+		for f := range data {
+			data[f].component = find(data[f].Points[0])
+		}
+	}
+	// crossings return coordinates X of crossing edges by horizontal line
+	crossings := func(halfs []int, y float64) (xs []float64) {
+		for _, h := range halfs {
+			e := edges[h/2]
+			xs = append(xs, e.crossings(m.Points[e.from], m.Points[e.to], y)...)
+		}
+		return
+	}
+	// inside return true for point inside of loop
+	inside := func(d loopData, p Point) bool {
+		in := false
+		for _, x := range crossings(d.half, p.Y) {
+			if p.X < x {
+				in = !in
+			}
+		}
+		return in
+	}
+
+	// bounded faces have counterclockwise loops with positive area,
+	// external boundaries of components have negative area
+	index := make([]int, len(data)) // index of region for each face
+	for f, d := range data {
+		index[f] = -1
+		if d.area <= 0 {
+			continue
+		}
+		index[f] = len(regions)
+		regions = append(regions, Region{
+			Outer:     d.Loop,
+			Area:      d.area,
+			Perimeter: d.perimeter,
+		})
+	}
+	for f, d := range data {
+		if 0 < d.area {
+			continue
+		}
+		// smallest face of other component around boundary
+		best := -1
+		for o, od := range data {
+			if od.area <= 0 || od.component == d.component ||
+				!inside(od, m.Points[d.Points[0]]) {
+				continue
+			}
+			if best < 0 || od.area < data[best].area {
+				best = o
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		r := &regions[index[best]]
+		r.Holes = append(r.Holes, data[f].Loop)
+		r.Area += d.area
+		r.Perimeter += d.perimeter
+	}
+
+	// point inside of region
+	for f, d := range data {
+		if index[f] < 0 {
+			continue
+		}
+		r := &regions[index[f]]
+		halfs := append([]int(nil), d.half...)
+		for _, hd := range data {
+			if hd.area <= 0 && containsLoop(r.Holes, hd.Loop) {
+				halfs = append(halfs, hd.half...)
+			}
+		}
+		// horizontal lines between levels of points
+		var ys []float64
+		for _, h := range halfs {
+			e := edges[h/2]
+			ys = append(ys, m.Points[e.from].Y, m.Points[e.to].Y)
+			for _, s := range e.extremes() {
+				ys = append(ys, e.point(s).Y)
+			}
+		}
+		sort.Float64s(ys)
+		// only levels with largest heights are checked
+		var ks []int
+		for k := 1; k < len(ys); k++ {
+			if ys[k-1] < ys[k] {
+				ks = append(ks, k)
+			}
+		}
+		sort.SliceStable(ks, func(i, j int) bool {
+			return ys[ks[j]]-ys[ks[j]-1] < ys[ks[i]]-ys[ks[i]-1]
+		})
+		if regionLevels < len(ks) {
+			ks = ks[:regionLevels]
+		}
+		best := 0.0
+		for _, k := range ks {
+			height := ys[k] - ys[k-1]
+			if height <= best {
+				break
+			}
+			// intervals between odd and even crossings are inside region
+			y := (ys[k] + ys[k-1]) / 2
+			xs := crossings(halfs, y)
+			sort.Float64s(xs)
+			for i := 1; i < len(xs); i += 2 {
+				if size := math.Min(xs[i]-xs[i-1], height); best < size {
+					best = size
+					r.Interior = Point{X: (xs[i] + xs[i-1]) / 2, Y: y}
+				}
+			}
+		}
+	}
+	return
+}
+
+// containsLoop return true if loop is in list
+func containsLoop(list []Loop, loop Loop) bool {
+	for _, l := range list {
+		if len(l.Edges) == len(loop.Edges) && l.Edges[0] == loop.Edges[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestRegions(t *testing.T) {
+	var m Model
+	// square with circle hole
+	m.AddLine(Point{0, 0}, Point{4, 0}, 1)
+	m.AddLine(Point{4, 0}, Point{4, 4}, 1)
+	m.AddLine(Point{4, 4}, Point{0, 4}, 1)
+	m.AddLine(Point{0, 4}, Point{0, 0}, 1)
+	m.AddCircle(2, 2, 1, 2)
+	// bridge between square and circle
+	m.AddLine(Point{0, 2}, Point{1, 2}, 3)
+	// open chain
+	m.AddLine(Point{4, 4}, Point{5, 5}, 3)
+	// region of line and arc
+	m.AddLine(Point{6, 0}, Point{6, 2}, 4)
+	m.AddArc(Point{6, 2}, Point{7, 1}, Point{6, 0}, 4)
+	if err := m.Intersection(); err != nil {
+		t.Fatal(err)
+	}
+
+	regions := m.Regions()
+	areas := []float64{16 - math.Pi, math.Pi / 2, math.Pi}
+	perimeters := []float64{16 + 2*math.Pi, 2 + math.Pi, 2 * math.Pi}
+	if len(regions) != len(areas) {
+		t.Fatalf("not valid amount of regions: %d", len(regions))
+	}
+	var sb strings.Builder
+	for i, r := range regions {
+		if math.Abs(r.Area-areas[i]) > 1e-9 {
+			t.Errorf("region %d: not valid area %.9e", i, r.Area)
+		}
+		if math.Abs(r.Perimeter-perimeters[i]) > 1e-9 {
+			t.Errorf("region %d: not valid perimeter %.9e", i, r.Perimeter)
+		}
+		fmt.Fprintf(&sb, "Region %d:\n", i)
+		fmt.Fprintf(&sb, "outer: %v\n", r.Outer)
+		for _, h := range r.Holes {
+			fmt.Fprintf(&sb, "hole:  %v\n", h)
+		}
+		fmt.Fprintf(&sb, "area:      %.6f\n", r.Area)
+		fmt.Fprintf(&sb, "perimeter: %.6f\n", r.Perimeter)
+		fmt.Fprintf(&sb, "interior:  %.6f\n", r.Interior)
+	}
+	compare.Test(t, filepath.Join("testdata", "Regions"), []byte(sb.String()))
+
+	// interior points are in regions
+	for i, in := range []func(p Point) bool{
+		func(p Point) bool {
+			return 0 < p.X && p.X < 4 && 0 < p.Y && p.Y < 4 &&
+				1 < Distance(p, Point{2, 2})
+		},
+		func(p Point) bool { return 6 < p.X && Distance(p, Point{6, 1}) < 1 },
+		func(p Point) bool { return Distance(p, Point{2, 2}) < 1 },
+	} {
+		if !in(regions[i].Interior) {
+			t.Errorf("region %d: point is outside %v", i, regions[i].Interior)
+		}
+	}
+
+	// empty model
+	if rs := (Model{}).Regions(); len(rs) != 0 {
+		t.Errorf("regions of empty model: %v", rs)
+	}
+}
+
+func TestRegionsLarge(t *testing.T) {
+	// polygon with many points
+	const size = 20000
+	var m Model
+	for i := 0; i < size; i++ {
+		a0 := 2 * math.Pi * float64(i) / size
+		a1 := 2 * math.Pi * float64(i+1) / size
+		m.AddLine(
+			Point{math.Cos(a0), math.Sin(a0)},
+			Point{math.Cos(a1), math.Sin(a1)},
+			1,
+		)
+	}
+	regions := m.Regions()
+	if len(regions) != 1 {
+		t.Fatalf("not valid amount of regions: %d", len(regions))
+	}
+	if r := regions[0]; len(r.Outer.Edges) != size ||
+		0.5 < Distance(r.Interior, Point{}) {
+		t.Errorf("not valid region: %d %v", len(r.Outer.Edges), r.Interior)
+	}
+}
@@ -0,0 +1,129 @@
+package gog
+
+import (
+	"math"
+	"sort"
+)
+
+// box is bounding box
+type box struct {
+	min, max Point
+}
+
+// boxOf return bounding box of points
+func boxOf(ps ...Point) (b box) {
+	b.min, b.max = ps[0], ps[0]
+	for _, p := range ps[1:] {
+		b.min.X = math.Min(b.min.X, p.X)
+		b.min.Y = math.Min(b.min.Y, p.Y)
+		b.max.X = math.Max(b.max.X, p.X)
+		b.max.Y = math.Max(b.max.Y, p.Y)
+	}
+	return
+}
+
+// overlap return true if boxes have common points
+func (b box) overlap(o box) bool {
+	return !(b.max.X < o.min.X || o.max.X < b.min.X ||
+		b.max.Y < o.min.Y || o.max.Y < b.min.Y)
+}
+
+// union return bounding box of both boxes
+func (b box) union(o box) box {
+	return boxOf(b.min, b.max, o.min, o.max)
+}
+
+// rtreeNodeSize is maximal amount of children in node of R-tree
+const rtreeNodeSize = 16
+
+// rtree is static R-tree of boxes created by Sort-Tile-Recursive algorithm
+type rtree struct {
+	boxes []box
+	nodes []rnode
+	root  int
+}
+
+// rnode is node of R-tree
+type rnode struct {
+	box      box
+	leaf     bool
+	children []int // indexes of nodes or indexes of boxes for leaf
+}
+
+// newRtree return R-tree for boxes
+func newRtree(boxes []box) *rtree {
+	t := &rtree{boxes: boxes, root: -1}
+	if len(boxes) == 0 {
+		return t
+	}
+	type entry struct {
+		box box
+		id  int
+	}
+	center := func(b box) Point {
+		return Point{X: (b.min.X + b.max.X) / 2, Y: (b.min.Y + b.max.Y) / 2}
+	}
+	level := make([]entry, len(boxes))
+	for i := range boxes {
+		level[i] = entry{box: boxes[i], id: i}
+	}
+	for leaf := true; ; leaf = false {
+		// sort tile recursive
+		var (
+			amount = (len(level) + rtreeNodeSize - 1) / rtreeNodeSize
+			strips = int(math.Ceil(math.Sqrt(float64(amount))))
+			size   = strips * rtreeNodeSize
+		)
+		sort.Slice(level, func(i, j int) bool {
+			return center(level[i].box).X < center(level[j].box).X
+		})
+		var next []entry
+		for s := 0; s < len(level); s += size {
+			strip := level[s:min(s+size, len(level))]
+			sort.Slice(strip, func(i, j int) bool {
+				return center(strip[i].box).Y < center(strip[j].box).Y
+			})
+			for g := 0; g < len(strip); g += rtreeNodeSize {
+				group := strip[g:min(g+rtreeNodeSize, len(strip))]
+				n := rnode{box: group[0].box, leaf: leaf}
+				for _, e := range group {
+					n.box = n.box.union(e.box)
+					n.children = append(n.children, e.id)
+				}
+				t.nodes = append(t.nodes, n)
+				next = append(next, entry{box: n.box, id: len(t.nodes) - 1})
+			}
+		}
+		if len(next) == 1 {
+			t.root = next[0].id
+			return t
+		}
+		level = next
+	}
+}
+
+// search return sorted indexes of boxes overlapped with box
+func (t *rtree) search(b box) (ids []int) {
+	if t.root < 0 {
+		return
+	}
+	stack := []int{t.root}
+	for 0 < len(stack) {
+		n := &t.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !n.box.overlap(b) {
+			continue
+		}
+		if !n.leaf {
+			stack = append(stack, n.children...)
+			continue
+		}
+		for _, id := range n.children {
+			if t.boxes[id].overlap(b) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return
+}
@@ -0,0 +1,129 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+// newLinear return R-tree with one leaf for all boxes.
+// Search in that tree is linear check of all boxes.
+func newLinear(boxes []box) *rtree {
+	t := &rtree{boxes: boxes, root: -1}
+	if len(boxes) == 0 {
+		return t
+	}
+	n := rnode{box: boxes[0], leaf: true}
+	for i := range boxes {
+		n.box = n.box.union(boxes[i])
+		n.children = append(n.children, i)
+	}
+	t.nodes = append(t.nodes, n)
+	t.root = 0
+	return t
+}
+
+// randomSegments return model with random segments, arcs, triangles
+// and points inside square. Length of segments is decreased for more
+// elements for constant density of intersections.
+func randomSegments(size int) (m Model) {
+	r := rand.New(rand.NewSource(0))
+	d := 2 / math.Sqrt(float64(size))
+	rp := func() Point {
+		return Point{X: r.Float64(), Y: r.Float64()}
+	}
+	near := func(p Point, d float64) Point {
+		return Point{X: p.X + d*(r.Float64()-0.5), Y: p.Y + d*(r.Float64()-0.5)}
+	}
+	for i := 0; i < size; i++ {
+		p := rp()
+		switch r.Intn(10) {
+		case 0:
+			m.AddArc(p, near(p, d), near(p, d), 2)
+		case 1:
+			m.AddTriangle(p, near(p, d), near(p, d), 3)
+		case 2:
+			m.AddPoint(p)
+		default:
+			m.AddLine(p, near(p, 2*d), 1)
+		}
+	}
+	return
+}
+
+func TestRtree(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, size := range []int{0, 1, 15, 16, 17, 300, 5000} {
+		boxes := make([]box, size)
+		for i := range boxes {
+			p := Point{X: r.Float64(), Y: r.Float64()}
+			boxes[i] = boxOf(p, Point{X: p.X + r.Float64()/10, Y: p.Y + r.Float64()/10})
+		}
+		tree := newRtree(boxes)
+		linear := newLinear(boxes)
+		for i := 0; i < 100; i++ {
+			p := Point{X: r.Float64(), Y: r.Float64()}
+			b := boxOf(p, Point{X: p.X + r.Float64()/5, Y: p.Y})
+			if a, e := fmt.Sprint(tree.search(b)), fmt.Sprint(linear.search(b)); a != e {
+				t.Fatalf("size %d: not same boxes:\n%s\n%s", size, a, e)
+			}
+		}
+	}
+}
+
+// TestIntersectionRtree compare intersection of models with golden files
+// created by intersection before R-tree and with linear search.
+func TestIntersectionRtree(t *testing.T) {
+	for _, size := range []int{10, 100, 500} {
+		t.Run(fmt.Sprintf("%d", size), func(t *testing.T) {
+			m1 := randomSegments(size)
+			m2 := randomSegments(size)
+			if err := m1.Intersection(); err != nil {
+				t.Fatal(err)
+			}
+			if err := m2.intersection(nil, newLinear); err != nil {
+				t.Fatal(err)
+			}
+			if a, e := m1.String(), m2.String(); a != e {
+				t.Errorf("models are not same")
+			}
+			compare.Test(t,
+				filepath.Join("testdata", fmt.Sprintf("IntersectionRtree%d", size)),
+				[]byte(m1.String()))
+		})
+	}
+}
+
+// cpu: Intel(R) Xeon(R) Processor
+// BenchmarkIntersection/Linear/100         	       3	   9094472 ns/op
+// BenchmarkIntersection/Linear/1000        	       3	 395334262 ns/op
+// BenchmarkIntersection/Linear/5000        	       3	15388773939 ns/op
+// BenchmarkIntersection/Rtree/100          	       3	  13071145 ns/op
+// BenchmarkIntersection/Rtree/1000         	       3	 271983781 ns/op
+// BenchmarkIntersection/Rtree/5000         	       3	2844161989 ns/op
+func BenchmarkIntersection(b *testing.B) {
+	for _, c := range []struct {
+		name    string
+		newTree func(boxes []box) *rtree
+	}{
+		{"Linear", newLinear},
+		{"Rtree", newRtree},
+	} {
+		for _, size := range []int{100, 1000, 5000} {
+			b.Run(fmt.Sprintf("%s/%d", c.name, size), func(b *testing.B) {
+				model := randomSegments(size)
+				b.ResetTimer()
+				for n := 0; n < b.N; n++ {
+					m := model.Copy()
+					if err := m.intersection(nil, c.newTree); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
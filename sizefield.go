@@ -0,0 +1,411 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	eTree "github.com/Konstantin8105/errors"
+)
+
+// SizeField is target size of elements in space.
+// Size must be positive for all points. For few workers of mesh, see
+// `Config`, size field must be safe for concurrent use.
+type SizeField interface {
+	Size(p Point) float64
+}
+
+// checkSize return error for not valid parameters of size field
+func checkSize(f SizeField) error {
+	v, ok := f.(interface{ validate() error })
+	if !ok {
+		return nil
+	}
+	return v.validate()
+}
+
+// checkGrowth return error for growth rate less 1
+func checkGrowth(growth float64) error {
+	if !(1 <= growth) {
+		return fmt.Errorf("growth rate is less 1: %f", growth)
+	}
+	return nil
+}
+
+// ConstantSize is same size of elements in all points
+type ConstantSize float64
+
+// Size return constant size
+func (c ConstantSize) Size(_ Point) float64 {
+	return float64(c)
+}
+
+// MinSize is minimal size of few size fields
+type MinSize []SizeField
+
+// validate return error for not valid size fields
+func (fs MinSize) validate() error {
+	for _, f := range fs {
+		if err := checkSize(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Size return minimal size of fields
+func (fs MinSize) Size(p Point) (size float64) {
+	size = math.MaxFloat64
+	for _, f := range fs {
+		size = math.Min(size, f.Size(p))
+	}
+	return
+}
+
+// graded return size on distance `d` from source with size `h`,
+// growth rate `growth` and maximal size `max`. If maximal size is zero,
+// then size is not limited.
+func graded(h, growth, d, max float64) float64 {
+	size := h + (growth-1)*d
+	if 0 < max && max < size {
+		size = max
+	}
+	return size
+}
+
+// segmentPointDistance return distance between point and segment
+func segmentPointDistance(p, p0, p1 Point) float64 {
+	var (
+		dx = p1.X - p0.X
+		dy = p1.Y - p0.Y
+		l2 = dx*dx + dy*dy
+	)
+	if l2 == 0 {
+		return Distance(p, p0)
+	}
+	t := ((p.X-p0.X)*dx + (p.Y-p0.Y)*dy) / l2
+	t = math.Max(0, math.Min(1, t))
+	return Distance(p, Point{X: p0.X + t*dx, Y: p0.Y + t*dy})
+}
+
+// LineSize is size graded by distance from lines of model with tags.
+// Size near lines is `Near` and increase by growth rate `Growth`
+// (ratio more or equal 1, checked by `SplitSize` and `Refine`) up to
+// `Max`. If `Max` is zero, then size is not limited. Arcs are used by
+// chord between first and last points.
+type LineSize struct {
+	Model  Model
+	Tags   []int
+	Near   float64
+	Growth float64
+	Max    float64
+}
+
+// validate return error for not valid growth rate
+func (f LineSize) validate() error {
+	return checkGrowth(f.Growth)
+}
+
+// Size return size by nearest line with tag
+func (f LineSize) Size(p Point) float64 {
+	d := math.MaxFloat64
+	tagged := func(tag int) bool {
+		for _, t := range f.Tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	}
+	for _, l := range f.Model.Lines {
+		if l[2] == Removed || !tagged(l[2]) {
+			continue
+		}
+		d = math.Min(d, segmentPointDistance(p, f.Model.Points[l[0]], f.Model.Points[l[1]]))
+	}
+	for _, a := range f.Model.Arcs {
+		if a[3] == Removed || !tagged(a[3]) {
+			continue
+		}
+		d = math.Min(d, segmentPointDistance(p, f.Model.Points[a[0]], f.Model.Points[a[2]]))
+	}
+	if d == math.MaxFloat64 {
+		// lines with tags are not found
+		if 0 < f.Max {
+			return f.Max
+		}
+		return f.Near
+	}
+	return graded(f.Near, f.Growth, d, f.Max)
+}
+
+// PointSource is point with size of elements
+type PointSource struct {
+	Point Point
+	Size  float64
+}
+
+// PointSize is size graded by distance from point sources. Size increase
+// by growth rate `Growth` (ratio more or equal 1, checked by `SplitSize`
+// and `Refine`) up to `Max`. If `Max` is zero, then size is not limited.
+type PointSize struct {
+	Sources []PointSource
+	Growth  float64
+	Max     float64
+}
+
+// validate return error for not valid growth rate
+func (f PointSize) validate() error {
+	return checkGrowth(f.Growth)
+}
+
+// Size return minimal size from all point sources
+func (f PointSize) Size(p Point) (size float64) {
+	if len(f.Sources) == 0 {
+		return f.Max
+	}
+	size = math.MaxFloat64
+	for _, s := range f.Sources {
+		size = math.Min(size, graded(s.Size, f.Growth, Distance(p, s.Point), f.Max))
+	}
+	return
+}
+
+// MeshSize is size interpolated on triangles of background mesh.
+// MeshSize is safe for concurrent use.
+type MeshSize struct {
+	mu    sync.Mutex // location in mesh changes mesh locator
+	mesh  *Mesh
+	sizes []float64
+}
+
+// NewMeshSize return size field by triangulated model and sizes in
+// points of model. Sizes are linear interpolated inside triangles.
+// Outside of triangles size of nearest point is used.
+func NewMeshSize(model Model, sizes []float64) (f *MeshSize, err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("NewMeshSize")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if len(sizes) != len(model.Points) {
+		err = fmt.Errorf("not same amount of points and sizes: %d != %d",
+			len(model.Points), len(sizes))
+		return
+	}
+	for i, s := range sizes {
+		if !(0 < s) {
+			err = fmt.Errorf("not valid size %f in point %d", s, i)
+			return
+		}
+	}
+	mesh, err := NewFromTriangles(model)
+	if err != nil {
+		return
+	}
+	f = &MeshSize{mesh: mesh, sizes: make([]float64, len(sizes))}
+	copy(f.sizes, sizes)
+	return
+}
+
+// Size return interpolated size in point
+func (f *MeshSize) Size(p Point) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ps := f.mesh.model.Points
+	if i := f.mesh.locate(p); 0 <= i {
+		tr := f.mesh.model.Triangles[i]
+		var (
+			a     = Area(ps[tr[0]], ps[tr[1]], ps[tr[2]])
+			size  float64
+			total float64
+		)
+		for j := 0; j < 3; j++ {
+			// barycentric coordinate
+			w := Area(p, ps[tr[(j+1)%3]], ps[tr[(j+2)%3]]) / a
+			size += w * f.sizes[tr[j]]
+			total += w
+		}
+		return size / total
+	}
+	// nearest point
+	var (
+		index = 0
+		d     = math.MaxFloat64
+	)
+	for i := range ps {
+		if dist := Distance(p, ps[i]); dist < d {
+			index, d = i, dist
+		}
+	}
+	return f.sizes[index]
+}
+
+// SplitSize split all model lines, arcs until length of each line or
+// arc is not more size of field in middle point
+func (m *Model) SplitSize(f SizeField) (err error) {
+	tol := m.tolerance()
+	defer func() {
+		if err != nil {
+			et := eTree.New("SplitSize")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if err = checkSize(f); err != nil {
+		return
+	}
+	size := func(p Point) (s float64, err error) {
+		s = f.Size(p)
+		if !(0 < s) {
+			err = fmt.Errorf("not valid size %f in point %.9f", s, p)
+		}
+		return
+	}
+	const maxIter = 50
+	{
+		// split lines
+		var lines [][3]int
+		for _, l := range m.Lines {
+			if l[2] == Removed {
+				lines = append(lines, l)
+				continue
+			}
+			segments := [][2]Point{{m.Points[l[0]], m.Points[l[1]]}}
+			for iter := 0; ; iter++ {
+				if iter == maxIter {
+					err = fmt.Errorf("too many iterations for line %v", l)
+					return
+				}
+				var next [][2]Point
+				split := false
+				for _, s := range segments {
+					mid := MiddlePoint(s[0], s[1])
+					var h float64
+					if h, err = size(mid); err != nil {
+						return
+					}
+					if Distance(s[0], s[1]) <= h {
+						next = append(next, s)
+						continue
+					}
+					split = true
+					next = append(next, [2]Point{s[0], mid}, [2]Point{mid, s[1]})
+				}
+				segments = next
+				if !split {
+					break
+				}
+			}
+			if len(segments) == 1 {
+				lines = append(lines, l)
+				continue
+			}
+			for _, s := range segments {
+				lines = append(lines, [3]int{m.AddPoint(s[0]), m.AddPoint(s[1]), l[2]})
+			}
+		}
+		m.Lines = lines
+	}
+	{
+		// split arcs
+		var arcs [][4]int
+		for ia, a := range m.Arcs {
+			if a[3] == Removed {
+				arcs = append(arcs, a)
+				continue
+			}
+			segments := [][3]Point{{m.Points[a[0]], m.Points[a[1]], m.Points[a[2]]}}
+			for iter := 0; ; iter++ {
+				if iter == maxIter {
+					err = fmt.Errorf("too many iterations for arc %v", a)
+					return
+				}
+				var next [][3]Point
+				split := false
+				for _, s := range segments {
+					var h float64
+					if h, err = size(s[1]); err != nil {
+						return
+					}
+					// preliminary calculation arc length
+					if 2.0*Distance(s[0], s[1]) <= h {
+						next = append(next, s)
+						continue
+					}
+					var res [][3]Point
+					if res, err = tol.ArcSplitByPoint(s[0], s[1], s[2]); err != nil {
+						err = &ElementError{
+							Err:    ErrNotValidArc,
+							Index:  ia,
+							Points: []int{a[0], a[1], a[2]},
+							Coords: s[:],
+						}
+						return
+					}
+					split = true
+					next = append(next, res...)
+				}
+				segments = next
+				if !split {
+					break
+				}
+			}
+			if len(segments) == 1 {
+				arcs = append(arcs, a)
+				continue
+			}
+			for _, s := range segments {
+				arcs = append(arcs, [4]int{
+					m.AddPoint(s[0]), m.AddPoint(s[1]), m.AddPoint(s[2]), a[3],
+				})
+			}
+		}
+		m.Arcs = arcs
+	}
+	return
+}
+
+// SplitSize split all triangles edge until length of edge is not more
+// size of field in middle of edge
+func (mesh *Mesh) SplitSize(f SizeField) (err error) {
+	defer func() {
+		if err != nil {
+			et := eTree.New("SplitSize")
+			_ = et.Add(err)
+			err = errorTree{et}
+		}
+	}()
+	if err = checkSize(f); err != nil {
+		return
+	}
+	var (
+		sizeErr error
+		mu      sync.Mutex // function is used by workers
+	)
+	err = mesh.SplitFunc(func(p1, p2 Point) bool {
+		mu.Lock()
+		invalid := sizeErr != nil
+		mu.Unlock()
+		if invalid {
+			return false
+		}
+		mid := MiddlePoint(p1, p2)
+		s := f.Size(mid)
+		if !(0 < s) {
+			mu.Lock()
+			if sizeErr == nil {
+				sizeErr = fmt.Errorf("not valid size %f in point %.9f", s, mid)
+			}
+			mu.Unlock()
+			return false
+		}
+		return s < Distance(p1, p2)
+	})
+	if err == nil {
+		err = sizeErr
+	}
+	return
+}
@@ -0,0 +1,211 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSizeField(t *testing.T) {
+	var lines Model
+	lines.AddLine(Point{0, 0}, Point{2, 0}, 1)
+	lines.AddLine(Point{0, 1}, Point{2, 1}, 2)
+
+	var background Model
+	background.AddTriangle(Point{0, 0}, Point{1, 0}, Point{0, 1}, 1)
+	background.AddTriangle(Point{1, 0}, Point{1, 1}, Point{0, 1}, 1)
+	sizes := make([]float64, len(background.Points))
+	for i, p := range background.Points {
+		sizes[i] = 1 + p.X + 2*p.Y
+	}
+	ms, err := NewMeshSize(background, sizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tc := range []struct {
+		f      SizeField
+		p      Point
+		expect float64
+	}{
+		{ConstantSize(0.5), Point{10, 10}, 0.5},
+		{LineSize{Model: lines, Tags: []int{1}, Near: 0.1, Growth: 1.5}, Point{1, 0}, 0.1},
+		{LineSize{Model: lines, Tags: []int{1}, Near: 0.1, Growth: 1.5}, Point{1, 0.4}, 0.3},
+		{LineSize{Model: lines, Tags: []int{1}, Near: 0.1, Growth: 1.5}, Point{3, 0}, 0.6},
+		{LineSize{Model: lines, Tags: []int{1}, Near: 0.1, Growth: 1.5, Max: 0.2}, Point{1, 0.4}, 0.2},
+		{LineSize{Model: lines, Tags: []int{2}, Near: 0.1, Growth: 1.5}, Point{1, 0.4}, 0.4},
+		{LineSize{Model: lines, Tags: []int{5}, Near: 0.1, Max: 0.7}, Point{1, 0.4}, 0.7},
+		{PointSize{Sources: []PointSource{{Point{0, 0}, 0.1}, {Point{4, 0}, 0.01}}, Growth: 2},
+			Point{1, 0}, 1.1},
+		{PointSize{Sources: []PointSource{{Point{0, 0}, 0.1}, {Point{4, 0}, 0.01}}, Growth: 2},
+			Point{3, 0}, 1.01},
+		{PointSize{Sources: []PointSource{{Point{0, 0}, 0.1}}, Growth: 2, Max: 0.5},
+			Point{3, 0}, 0.5},
+		{MinSize{ConstantSize(0.5), ConstantSize(0.3)}, Point{0, 0}, 0.3},
+		{ms, Point{0, 0}, 1},
+		{ms, Point{1, 1}, 4},
+		{ms, Point{0.5, 0.25}, 2},
+		{ms, Point{0.5, 0.5}, 2.5},
+		{ms, Point{5, 5}, 4},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if size := tc.f.Size(tc.p); math.Abs(size-tc.expect) > 1e-9 {
+				t.Errorf("not valid size: %f != %f", size, tc.expect)
+			}
+		})
+	}
+}
+
+func TestNewMeshSizeError(t *testing.T) {
+	var m Model
+	m.AddTriangle(Point{0, 0}, Point{1, 0}, Point{0, 1}, 1)
+	for i, sizes := range [][]float64{
+		{1, 1},
+		{1, 0, 1},
+		{1, math.NaN(), 1},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if _, err := NewMeshSize(m, sizes); err == nil {
+				t.Fatalf("error is not found")
+			} else {
+				t.Logf("%v", err)
+			}
+		})
+	}
+}
+
+func TestModelSplitSize(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{4, 0}, 1)
+	m.AddArc(Point{0, 1}, Point{2, 3}, Point{4, 1}, 2)
+	f := PointSize{Sources: []PointSource{{Point{0, 0}, 0.05}}, Growth: 1.3, Max: 0.5}
+	if err := m.SplitSize(f); err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range m.Lines {
+		p0, p1 := m.Points[l[0]], m.Points[l[1]]
+		if size := f.Size(MiddlePoint(p0, p1)); size < Distance(p0, p1) {
+			t.Errorf("line is too long: %f > %f", Distance(p0, p1), size)
+		}
+	}
+	for _, a := range m.Arcs {
+		p0, p1 := m.Points[a[0]], m.Points[a[1]]
+		if size := f.Size(p1); size < 2*Distance(p0, p1) {
+			t.Errorf("arc is too long: %f > %f", 2*Distance(p0, p1), size)
+		}
+	}
+	// graded lines
+	var near, far float64 = math.MaxFloat64, 0
+	for _, l := range m.Lines {
+		d := Distance(m.Points[l[0]], m.Points[l[1]])
+		near = math.Min(near, d)
+		far = math.Max(far, d)
+	}
+	if 2*near > far {
+		t.Errorf("lines are not graded: %f %f", near, far)
+	}
+	t.Logf("lines: %d, arcs: %d", len(m.Lines), len(m.Arcs))
+
+	if err := m.SplitSize(ConstantSize(-1)); err == nil {
+		t.Errorf("error is not found")
+	}
+}
+
+func TestMeshSplitSize(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{2, 0}, 1)
+	m.AddLine(Point{2, 0}, Point{2, 2}, 1)
+	m.AddLine(Point{2, 2}, Point{0, 2}, 1)
+	m.AddLine(Point{0, 2}, Point{0, 0}, 1)
+	f := PointSize{Sources: []PointSource{{Point{0, 0}, 0.05}}, Growth: 1.5, Max: 0.5}
+	if err := m.SplitSize(f); err != nil {
+		t.Fatal(err)
+	}
+	mesh, err := New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.SplitSize(f); err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Check(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		for j := 0; j < 3; j++ {
+			p0, p1 := mesh.model.Points[tr[j]], mesh.model.Points[tr[(j+1)%3]]
+			// movable points are smoothed after splitting
+			if size := f.Size(MiddlePoint(p0, p1)); 1.1*size < Distance(p0, p1) {
+				t.Errorf("side is too long: %f > %f", Distance(p0, p1), size)
+			}
+		}
+	}
+
+	// refinement by size field
+	mesh, err = New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Refine(RefineOptions{MinAngle: 20, Size: f}); err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Check(); err != nil {
+		t.Fatal(err)
+	}
+	var near, far int
+	for _, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		ps := [3]Point{
+			mesh.model.Points[tr[0]],
+			mesh.model.Points[tr[1]],
+			mesh.model.Points[tr[2]],
+		}
+		c := Point{X: (ps[0].X + ps[1].X + ps[2].X) / 3, Y: (ps[0].Y + ps[1].Y + ps[2].Y) / 3}
+		size := f.Size(c)
+		if math.Sqrt(3)/4*size*size < Area(ps[0], ps[1], ps[2]) {
+			t.Errorf("triangle is too big")
+		}
+		if c.X < 0.5 && c.Y < 0.5 {
+			near++
+		}
+		if 1.5 < c.X && 1.5 < c.Y {
+			far++
+		}
+	}
+	if near < 2*far {
+		t.Errorf("triangles are not graded: %d %d", near, far)
+	}
+
+	// not valid size field
+	mesh, err = New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.SplitSize(ConstantSize(0)); err == nil {
+		t.Errorf("error is not found")
+	}
+	if err = mesh.Refine(RefineOptions{Size: ConstantSize(-1)}); err == nil {
+		t.Errorf("error is not found")
+	}
+	// not valid growth rate
+	for _, g := range []SizeField{
+		PointSize{Sources: f.Sources, Growth: 0.5},
+		LineSize{Model: m, Tags: []int{1}, Near: 0.1},
+		MinSize{ConstantSize(1), PointSize{Sources: f.Sources, Growth: -1}},
+	} {
+		if err = m.SplitSize(g); err == nil {
+			t.Errorf("model: error is not found for %v", g)
+		}
+		if err = mesh.SplitSize(g); err == nil {
+			t.Errorf("mesh: error is not found for %v", g)
+		}
+		if err = mesh.Refine(RefineOptions{Size: g}); err == nil {
+			t.Errorf("refine: error is not found for %v", g)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// SVGOptions is options of svg drawing
+type SVGOptions struct {
+	// Width of image in pixels. If value is zero, then width is 800 pixels.
+	// Height of image is calculated by size of model
+	Width int
+
+	// PointLabels add index of points
+	PointLabels bool
+
+	// ElementLabels add index of lines, arcs, triangles, quadrs
+	ElementLabels bool
+
+	// TextHeight is height of labels. If value is zero, then
+	// height is calculated by size of model
+	TextHeight float64
+
+	// Color return color of tag in svg format, for example: "#ff0000".
+	// If function is nil, then used default palette
+	Color func(tag int) string
+}
+
+// svgPalette is default colors for tags
+var svgPalette = [...]string{
+	"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b",
+	"#e377c2", "#7f7f7f", "#bcbd22", "#17becf", "#aec7e8", "#ffbb78",
+}
+
+// svgColor return default color of tag
+func svgColor(tag int) string {
+	return svgPalette[(tag%len(svgPalette)+len(svgPalette))%len(svgPalette)]
+}
+
+// SVG return string in svg image format
+func (m Model) SVG(opts SVGOptions) string {
+	// create buffer
+	var buf bytes.Buffer
+	// writing into buffer is without errors
+	_ = m.WriteSVG(&buf, opts)
+	return buf.String()
+}
+
+// WriteSVG write model in svg image format. Points, lines, arcs,
+// triangles and quadrs are drawn with colors of tags.
+// Axe Y of model is directed to up of image.
+func (m Model) WriteSVG(w io.Writer, opts SVGOptions) (err error) {
+	// create buffer
+	var buf bytes.Buffer
+
+	if opts.Width <= 0 {
+		opts.Width = 800
+	}
+	if opts.Color == nil {
+		opts.Color = svgColor
+	}
+
+	// border of image
+	min, max := Point{X: -1, Y: -1}, Point{X: 1, Y: 1}
+	if 0 < len(m.Points) {
+		min, max = BorderPoints2d(m.Points...)
+	}
+	size := math.Max(max.X-min.X, max.Y-min.Y)
+	if size < Eps {
+		size = 1.0
+	}
+	margin := size * 0.05
+	var (
+		width  = max.X - min.X + 2*margin
+		height = max.Y - min.Y + 2*margin
+	)
+	if opts.TextHeight <= 0 {
+		opts.TextHeight = size / 50.0
+	}
+
+	// coordinate in svg format
+	f := func(v float64) string {
+		if v == 0 {
+			// ignore negative zero
+			v = 0
+		}
+		return fmt.Sprintf("%.8g", v)
+	}
+	// point in svg format with opposite axe Y
+	pf := func(p Point) string {
+		return f(p.X) + "," + f(-p.Y)
+	}
+
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" "+
+		"width=\"%d\" height=\"%d\" viewBox=\"%s %s %s %s\">\n",
+		opts.Width, int(float64(opts.Width)*height/width),
+		f(min.X-margin), f(-max.Y-margin), f(width), f(height))
+
+	// draw polygons
+	polygon := func(tag int, ps ...int) {
+		fmt.Fprintf(&buf, "<polygon points=\"")
+		for i, p := range ps {
+			if 0 < i {
+				fmt.Fprintf(&buf, " ")
+			}
+			fmt.Fprintf(&buf, "%s", pf(m.Points[p]))
+		}
+		fmt.Fprintf(&buf, "\" fill=\"%s\" fill-opacity=\"0.5\" stroke=\"black\" "+
+			"stroke-width=\"1\" vector-effect=\"non-scaling-stroke\"/>\n",
+			opts.Color(tag))
+	}
+	fmt.Fprintf(&buf, "<g id=\"triangles\">\n")
+	for _, tr := range m.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		polygon(tr[3], tr[:3]...)
+	}
+	fmt.Fprintf(&buf, "</g>\n")
+	fmt.Fprintf(&buf, "<g id=\"quadrs\">\n")
+	for _, q := range m.Quadrs {
+		if q[0] == Removed {
+			continue
+		}
+		polygon(q[4], q[:4]...)
+	}
+	fmt.Fprintf(&buf, "</g>\n")
+
+	// draw lines
+	fmt.Fprintf(&buf, "<g id=\"lines\">\n")
+	for _, l := range m.Lines {
+		if l[0] == Removed || l[2] == Removed {
+			continue
+		}
+		fmt.Fprintf(&buf, "<path d=\"M %s L %s\" stroke=\"%s\" "+
+			"stroke-width=\"2\" vector-effect=\"non-scaling-stroke\"/>\n",
+			pf(m.Points[l[0]]), pf(m.Points[l[1]]), opts.Color(l[2]))
+	}
+	fmt.Fprintf(&buf, "</g>\n")
+
+	// draw arcs
+	fmt.Fprintf(&buf, "<g id=\"arcs\">\n")
+	for _, a := range m.Arcs {
+		if a[0] == Removed || a[3] == Removed {
+			continue
+		}
+		var (
+			st = m.Points[a[0]]
+			mi = m.Points[a[1]]
+			en = m.Points[a[2]]
+			d  string
+		)
+		switch or := Orientation(st, mi, en); or {
+		case CollinearPoints:
+			d = fmt.Sprintf("M %s L %s", pf(st), pf(en))
+		default:
+			xc, yc, r, err := Arc(st, mi, en)
+			if err != nil {
+				// arc without circle is line
+				d = fmt.Sprintf("M %s L %s", pf(st), pf(en))
+				break
+			}
+			angle := func(p Point) float64 {
+				return math.Atan2(p.Y-yc, p.X-xc)
+			}
+			span := angle(en) - angle(st)
+			// axe Y of image is opposite, so counterclockwise arc in
+			// model is arc with negative angle direction in image
+			sweep := 0
+			if or == ClockwisePoints {
+				span = -span
+				sweep = 1
+			}
+			for span < 0 {
+				span += 2 * math.Pi
+			}
+			large := 0
+			if math.Pi < span {
+				large = 1
+			}
+			d = fmt.Sprintf("M %s A %s %s 0 %d %d %s",
+				pf(st), f(r), f(r), large, sweep, pf(en))
+		}
+		fmt.Fprintf(&buf, "<path d=\"%s\" fill=\"none\" stroke=\"%s\" "+
+			"stroke-width=\"2\" vector-effect=\"non-scaling-stroke\"/>\n",
+			d, opts.Color(a[3]))
+	}
+	fmt.Fprintf(&buf, "</g>\n")
+
+	// draw points
+	fmt.Fprintf(&buf, "<g id=\"points\">\n")
+	for _, p := range m.Points {
+		fmt.Fprintf(&buf, "<circle cx=\"%s\" cy=\"%s\" r=\"%s\" fill=\"black\"/>\n",
+			f(p.X), f(-p.Y), f(size/400.0))
+	}
+	fmt.Fprintf(&buf, "</g>\n")
+
+	// draw labels
+	text := func(str string, ps ...int) {
+		var c Point
+		for _, p := range ps {
+			c.X += m.Points[p].X
+			c.Y += m.Points[p].Y
+		}
+		c.X /= float64(len(ps))
+		c.Y /= float64(len(ps))
+		fmt.Fprintf(&buf, "<text x=\"%s\" y=\"%s\" font-size=\"%s\">%s</text>\n",
+			f(c.X), f(-c.Y), f(opts.TextHeight), str)
+	}
+	if opts.PointLabels {
+		fmt.Fprintf(&buf, "<g id=\"labels_points\">\n")
+		for i := range m.Points {
+			text(fmt.Sprintf("%d", i), i)
+		}
+		fmt.Fprintf(&buf, "</g>\n")
+	}
+	if opts.ElementLabels {
+		fmt.Fprintf(&buf, "<g id=\"labels_elements\" fill=\"blue\">\n")
+		for i, l := range m.Lines {
+			if l[0] == Removed || l[2] == Removed {
+				continue
+			}
+			text(fmt.Sprintf("%d", i), l[:2]...)
+		}
+		for i, a := range m.Arcs {
+			if a[0] == Removed || a[3] == Removed {
+				continue
+			}
+			text(fmt.Sprintf("%d", i), a[1])
+		}
+		for i, tr := range m.Triangles {
+			if tr[0] == Removed {
+				continue
+			}
+			text(fmt.Sprintf("%d", i), tr[:3]...)
+		}
+		for i, q := range m.Quadrs {
+			if q[0] == Removed {
+				continue
+			}
+			text(fmt.Sprintf("%d", i), q[:4]...)
+		}
+		fmt.Fprintf(&buf, "</g>\n")
+	}
+
+	fmt.Fprintf(&buf, "</svg>\n")
+
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// SVG return string in svg image format with all triangles and fixed
+// lines of mesh
+func (mesh *Mesh) SVG(opts SVGOptions) string {
+	m := Model{Points: mesh.model.Points}
+	for _, l := range mesh.model.Lines {
+		if l[2] == Removed {
+			continue
+		}
+		m.Lines = append(m.Lines, l)
+	}
+	for _, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		m.Triangles = append(m.Triangles, tr)
+	}
+	return m.SVG(opts)
+}
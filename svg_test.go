@@ -0,0 +1,64 @@
+package gog
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestSVG(t *testing.T) {
+	var m Model
+	m.AddCircle(0, 0, 1, 1)
+	m.AddArc(Point{2, 0}, Point{3, 1}, Point{4, 0}, 2)
+	// arc with angle 270 degree
+	m.AddArc(
+		Point{6, 1},
+		Point{6 + math.Sqrt2/2, -math.Sqrt2 / 2},
+		Point{5, 0},
+		2,
+	)
+	m.AddLine(Point{-1, 0}, Point{1, 0}, 3)
+	m.AddTriangle(Point{5, 2}, Point{6, 2}, Point{5, 3}, 4)
+	m.Quadrs = append(m.Quadrs, [5]int{
+		m.AddPoint(Point{7, 0}),
+		m.AddPoint(Point{8, 0}),
+		m.AddPoint(Point{8, 1}),
+		m.AddPoint(Point{7, 1}),
+		5,
+	})
+	var buf bytes.Buffer
+	if err := m.WriteSVG(&buf, SVGOptions{
+		PointLabels:   true,
+		ElementLabels: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != m.SVG(SVGOptions{PointLabels: true, ElementLabels: true}) {
+		t.Errorf("not same svg")
+	}
+	compare.Test(t, filepath.Join("testdata", "SVG"), buf.Bytes())
+}
+
+func TestSVGMesh(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+	m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+	m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+	mesh, err := New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Split(0.5); err != nil {
+		t.Fatal(err)
+	}
+	svg := mesh.SVG(SVGOptions{Color: func(tag int) string { return "red" }})
+	if !strings.Contains(svg, "<polygon") {
+		t.Errorf("triangles are not found")
+	}
+	compare.Test(t, filepath.Join("testdata", "SVGMesh"), []byte(svg))
+}
@@ -0,0 +1,149 @@
+package gog
+
+import "math"
+
+// Tolerance is precision of geometry operations. Zero value of tolerance
+// is default precision by global `Eps` and `Eps3D`.
+//
+// All geometry functions with tolerance have same method of tolerance:
+//
+//	gog.PointLine(pt, pt0, pt1)                     // precision `Eps`
+//	gog.Tolerance{Eps: 1e-6}.PointLine(pt, pt0, pt1) // precision 1e-6
+type Tolerance struct {
+	// Eps is epsilon for 2D operations. If value is zero, then
+	// global `Eps` is used.
+	Eps float64
+
+	// Eps3D is epsilon for 3D operations. If value is zero, then
+	// constant `Eps3D` is used.
+	Eps3D float64
+
+	// Relative is true for epsilon relative to size of bounding box.
+	// Model and mesh use bounding box of model points, see `Absolute`.
+	// Geometry functions use epsilon as absolute value.
+	Relative bool
+}
+
+// eps return epsilon for 2D operations
+func (t Tolerance) eps() float64 {
+	if t.Eps == 0 {
+		return Eps
+	}
+	return t.Eps
+}
+
+// eps3D return epsilon for 3D operations
+func (t Tolerance) eps3D() float64 {
+	if t.Eps3D == 0 {
+		return Eps3D
+	}
+	return t.Eps3D
+}
+
+// Absolute return tolerance with absolute epsilon. For relative tolerance
+// epsilon is multiplied by maximal size of bounding box of points.
+// If points are not exist or size is zero, then epsilon is not changed.
+func (t Tolerance) Absolute(ps ...Point) Tolerance {
+	if !t.Relative {
+		return t
+	}
+	var size float64
+	if 0 < len(ps) {
+		min, max := BorderPoints2d(ps...)
+		size = math.Max(max.X-min.X, max.Y-min.Y)
+	}
+	return t.scale(size)
+}
+
+// scale return absolute tolerance for relative tolerance and size of
+// bounding box
+func (t Tolerance) scale(size float64) Tolerance {
+	if !t.Relative {
+		return t
+	}
+	a := Tolerance{Eps: t.eps(), Eps3D: t.eps3D()}
+	if 0 < size && !math.IsInf(size, 0) {
+		a.Eps *= size
+		a.Eps3D *= size
+	}
+	return a
+}
+
+// Config is configuration of model and mesh operations. Zero value of
+// configuration is default configuration by global values `Eps`,
+// `Eps3D`, `Debug`, `Log` and `ExactPredicates`.
+type Config struct {
+	// Tolerance is precision of operations
+	Tolerance
+
+	// Debug only for debugging. Debugging is on, if global `Debug` is true
+	Debug bool
+
+	// Log only for minimal logging. Logging is on, if global `Log` is true
+	Log bool
+
+	// ExactPredicates switch triangulation to adaptive exact predicates.
+	// Exact predicates are used, if global `ExactPredicates` is true
+	ExactPredicates bool
+
+	// Workers is amount of goroutines for parallel operations
+	// `Model.Intersection`, `Model.Split` and `Mesh.SplitFunc`.
+	// Operations are sequential, if value is less 2. Result of
+	// operations is same for any amount of workers.
+	Workers int
+}
+
+// SetConfig change configuration of model operations
+func (m *Model) SetConfig(c Config) {
+	m.config = c
+}
+
+// Config return configuration of model operations
+func (m Model) Config() Config {
+	return m.config
+}
+
+// tolerance return absolute tolerance of model operations. Relative
+// tolerance is calculated by bounding box of model points.
+func (m *Model) tolerance() Tolerance {
+	if !m.config.Relative {
+		return m.config.Tolerance
+	}
+	var size float64
+	if b, ok := m.bounds(); ok {
+		size = math.Max(b.max.X-b.min.X, b.max.Y-b.min.Y)
+	}
+	return m.config.Tolerance.scale(size)
+}
+
+// debug return true for debugging of operations
+func (m *Model) debug() bool {
+	return Debug || m.config.Debug
+}
+
+// log return true for logging of operations
+func (m *Model) log() bool {
+	return Log || m.config.Log
+}
+
+// exact return true for exact predicates in triangulation
+func (m *Model) exact() bool {
+	return ExactPredicates || m.config.ExactPredicates
+}
+
+// workers return amount of goroutines for parallel operations
+func (m *Model) workers() int {
+	return max(1, m.config.Workers)
+}
+
+// SetConfig change configuration of mesh operations.
+// Relative tolerance is calculated by bounding box of mesh points.
+func (mesh *Mesh) SetConfig(c Config) {
+	mesh.model.config = c
+	mesh.model.config.Tolerance = mesh.model.tolerance()
+}
+
+// Config return configuration of mesh operations
+func (mesh Mesh) Config() Config {
+	return mesh.model.config
+}
@@ -0,0 +1,147 @@
+package gog
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestTolerance(t *testing.T) {
+	// default tolerance
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 1000; i++ {
+		var ps [4]Point
+		for j := range ps {
+			ps[j] = Point{X: float64(r.Intn(5)), Y: float64(r.Intn(5))}
+		}
+		var tol Tolerance
+		if a, e := fmt.Sprint(tol.LineLine(ps[0], ps[1], ps[2], ps[3])),
+			fmt.Sprint(LineLine(ps[0], ps[1], ps[2], ps[3])); a != e {
+			t.Fatalf("not same results: %s != %s", a, e)
+		}
+		if a, e := tol.Orientation(ps[0], ps[1], ps[2]),
+			Orientation(ps[0], ps[1], ps[2]); a != e {
+			t.Fatalf("not same orientation: %v != %v", a, e)
+		}
+	}
+	// custom epsilon
+	p0, p1 := Point{0, 0}, Point{1e-7, 0}
+	if SamePoints(p0, p1) {
+		t.Errorf("points are same for default tolerance")
+	}
+	if !(Tolerance{Eps: 1e-6}).SamePoints(p0, p1) {
+		t.Errorf("points are not same for tolerance")
+	}
+	if !(Tolerance{Eps3D: 1e-3}).SamePoints3d(Point3d{}, Point3d{1e-4, 0, 0}) {
+		t.Errorf("points are not same for 3D tolerance")
+	}
+	// relative tolerance
+	rel := Tolerance{Eps: 1e-3, Relative: true}
+	if a := rel.Absolute(Point{0, 0}, Point{2, 1}); a.Eps != 2e-3 || a.Relative {
+		t.Errorf("not valid absolute tolerance: %v", a)
+	}
+	if a := rel.Absolute(); a.Eps != 1e-3 {
+		t.Errorf("not valid tolerance without points: %v", a)
+	}
+	if a := (Tolerance{Eps: 1e-3}).Absolute(Point{0, 0}, Point{2, 1}); a.Eps != 1e-3 {
+		t.Errorf("absolute tolerance is changed: %v", a)
+	}
+}
+
+func TestModelTolerance(t *testing.T) {
+	var m Model
+	m.SetConfig(Config{Tolerance: Tolerance{Eps: 1e-3}})
+	m.AddPoint(Point{0, 0})
+	if index := m.AddPoint(Point{1e-4, 1e-4}); index != 0 {
+		t.Errorf("point is added with tolerance: %d", index)
+	}
+	m.AddPoint(Point{1, 0})
+	if c := m.Copy(); c.Config() != m.Config() {
+		t.Errorf("configuration is not copied")
+	}
+	// relative tolerance by bounding box of points
+	m.SetConfig(Config{Tolerance: Tolerance{Eps: 1e-3, Relative: true}})
+	if index := m.AddPoint(Point{1e-4, 1e-4}); index != 0 {
+		t.Errorf("point is added with relative tolerance: %d", index)
+	}
+	m.AddPoint(Point{100, 0})
+	if index := m.AddPoint(Point{0.05, 0}); index != 0 {
+		t.Errorf("point is added with relative tolerance: %d", index)
+	}
+	if a := m.tolerance(); a.Eps != 1e-1 {
+		t.Errorf("not valid tolerance: %v", a)
+	}
+}
+
+func TestConfigScales(t *testing.T) {
+	// square with random points at different scales
+	model := func(scale float64, c Config) (m Model) {
+		m.SetConfig(c)
+		m.AddLine(Point{0, 0}, Point{scale, 0}, 1)
+		m.AddLine(Point{scale, 0}, Point{scale, scale}, 1)
+		m.AddLine(Point{scale, scale}, Point{0, scale}, 1)
+		m.AddLine(Point{0, scale}, Point{0, 0}, 1)
+		r := rand.New(rand.NewSource(0))
+		for i := 0; i < 100; i++ {
+			m.AddPoint(Point{
+				X: scale * (0.01 + 0.98*r.Float64()),
+				Y: scale * (0.01 + 0.98*r.Float64()),
+			})
+		}
+		return
+	}
+	relative := Config{Tolerance: Tolerance{Relative: true}}
+	for _, tc := range []struct {
+		scale float64
+		c     Config
+	}{
+		{1, Config{}},
+		{1e-3, relative},
+		{1e-6, relative},
+		{1e-6, Config{Tolerance: Tolerance{Eps: 1e-16}}},
+		{1e3, relative},
+		{1e6, relative},
+	} {
+		t.Run(fmt.Sprintf("%.0e", tc.scale), func(t *testing.T) {
+			t.Parallel()
+			mesh, err := New(model(tc.scale, tc.c))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = mesh.Check(); err != nil {
+				t.Fatal(err)
+			}
+			if n := len(mesh.model.Points); n != 104 {
+				t.Errorf("not valid amount of points: %d", n)
+			}
+		})
+	}
+	// meshing in parallel with different scales
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			scale := 1e-6
+			if i%2 == 0 {
+				scale = 1e6
+			}
+			var mesh *Mesh
+			mesh, errs[i] = New(model(scale, relative))
+			if errs[i] == nil {
+				errs[i] = mesh.Split(scale / 10)
+			}
+			if errs[i] == nil {
+				errs[i] = mesh.Check()
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := range errs {
+		if errs[i] != nil {
+			t.Errorf("model %d: %v", i, errs[i])
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log"
 	"math"
 	"runtime/debug"
+	"slices"
 	"sort"
 
 	eTree "github.com/Konstantin8105/errors"
@@ -37,19 +38,24 @@ import (
 //	+------------------------------------+
 type Mesh struct {
 	model     Model
-	Points    []int    // tags for points
-	Triangles [][3]int // indexes of near triangles
-	// TODO
-	templorary struct {
-		ignore []bool
-	}
+	Points    []int     // tags for points
+	Triangles [][3]int  // indexes of near triangles
+	locator   locator   // only for fast location of points
+	lines     lineIndex // only for fast search of lines by points
+	progress  *progress // cancellation and progress of operation
 }
 
+// Global values are default for all models and meshes.
+// For specific model or mesh see `Config`.
 var (
 	// Debug only for debugging
 	Debug = false
 	// Log only for minimal logging
 	Log = false
+	// ExactPredicates switch triangulation to adaptive exact predicates
+	// Orient2D and InCircle for checking of triangle orientation and
+	// Delaunay condition
+	ExactPredicates = false
 )
 
 const (
@@ -67,14 +73,19 @@ const (
 
 // New triangulation created by model
 func New(model Model) (mesh *Mesh, err error) {
-	if Log {
+	return newMesh(nil, model)
+}
+
+// newMesh return triangulation created by model with progress of operation
+func newMesh(p *progress, model Model) (mesh *Mesh, err error) {
+	if model.log() {
 		log.Printf("New")
 	}
 	defer func() {
 		if err != nil {
 			et := eTree.New("New")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
 	defer func() {
@@ -83,18 +94,29 @@ func New(model Model) (mesh *Mesh, err error) {
 			_ = et.Add(fmt.Errorf("%v", r))
 			_ = et.Add(fmt.Errorf("stacktrace from panic: %s",
 				string(debug.Stack())))
-			err = et
+			err = errorTree{et}
 		}
 	}()
+	// input model is not changed
+	model = model.Copy()
 	// prepare model before triangulation
-	model.Intersection()
+	if err = model.intersection(p, newRtree); err != nil {
+		return
+	}
 	if 0 < len(model.Arcs) {
 		model.ArcsToLines()
 	}
 	// create a new Mesh
 	mesh = new(Mesh)
+	mesh.progress = p
+	defer func() {
+		mesh.progress = nil
+	}()
+	// configuration with absolute tolerance by input model
+	mesh.model.config = model.config
+	mesh.model.config.Tolerance = model.tolerance()
 	// convex
-	_, cps := ConvexHull(model.Points, true) // points on convex hull
+	_, cps := mesh.model.tolerance().ConvexHull(model.Points, true) // points on convex hull
 	if len(cps) < 3 {
 		err = fmt.Errorf("not enought points for convex. Amount: %d", len(cps))
 		return
@@ -118,8 +140,10 @@ func New(model Model) (mesh *Mesh, err error) {
 	// last not exist triangle and mark as boundary
 	mesh.Triangles[len(mesh.Triangles)-1][2] = Boundary
 	// clockwise all triangles
-	mesh.Clockwise()
-	if Debug {
+	if err = mesh.Clockwise(); err != nil {
+		return
+	}
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("after convex: %v", err)
@@ -128,7 +152,10 @@ func New(model Model) (mesh *Mesh, err error) {
 	}
 	// add all points of model
 	for i := range model.Points {
-		if Debug {
+		if err = mesh.progress.report("New: add points", i, len(model.Points)); err != nil {
+			return
+		}
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				err = fmt.Errorf("Check 0 {%d}: %v", i, err)
@@ -140,10 +167,10 @@ func New(model Model) (mesh *Mesh, err error) {
 			et := eTree.New("In add point")
 			_ = et.Add(fmt.Errorf("point %d of %d", i, len(model.Points)))
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 			return
 		}
-		if Debug {
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				err = fmt.Errorf("Check 1 {%d}: %v", i, err)
@@ -151,7 +178,7 @@ func New(model Model) (mesh *Mesh, err error) {
 			}
 		}
 	}
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("Check 2: %v", err)
@@ -163,7 +190,7 @@ func New(model Model) (mesh *Mesh, err error) {
 	if err != nil {
 		return
 	}
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("Check 3: %v", err)
@@ -171,7 +198,7 @@ func New(model Model) (mesh *Mesh, err error) {
 		}
 	}
 	// add fixed tags
-	if Debug {
+	if mesh.model.debug() {
 		if len(mesh.Points) != len(mesh.model.Points) {
 			err = fmt.Errorf("not equal points size: %d != %d ",
 				len(mesh.Points),
@@ -186,13 +213,16 @@ func New(model Model) (mesh *Mesh, err error) {
 
 	// add fixed lines
 	for i := range model.Lines {
+		if err = mesh.progress.report("New: add lines", i, len(model.Lines)); err != nil {
+			return
+		}
 		if err = mesh.AddLine(
 			model.Points[model.Lines[i][0]],
 			model.Points[model.Lines[i][1]],
 		); err != nil {
 			return
 		}
-		if Debug {
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				err = fmt.Errorf("Check 5: %v", err)
@@ -206,6 +236,7 @@ func New(model Model) (mesh *Mesh, err error) {
 
 // Check triangulation on point, line, triangle rules
 func (mesh Mesh) Check() (err error) {
+	tol := mesh.model.tolerance()
 	// if Log {
 	// 	log.Printf("Check")
 	// }
@@ -213,7 +244,7 @@ func (mesh Mesh) Check() (err error) {
 	defer func() {
 		if et.IsError() {
 			_ = et.Add(fmt.Errorf("amount of points: %5d", len(mesh.model.Points)))
-			err = et
+			err = errorTree{et}
 		}
 	}()
 	// amount of triangles
@@ -226,7 +257,7 @@ func (mesh Mesh) Check() (err error) {
 			if i <= j {
 				continue
 			}
-			if Distance(mesh.model.Points[i], mesh.model.Points[j]) < Eps {
+			if Distance(mesh.model.Points[i], mesh.model.Points[j]) < tol.eps() {
 				_ = et.Add(fmt.Errorf("same points %v and %v", i, j))
 			}
 		}
@@ -238,7 +269,7 @@ func (mesh Mesh) Check() (err error) {
 		for _, d := range [3][2]int{{0, 1}, {1, 2}, {2, 0}} {
 			id0 := mesh.model.Triangles[i][d[0]]
 			id1 := mesh.model.Triangles[i][d[1]]
-			if Distance(mesh.model.Points[id0], mesh.model.Points[id1]) < Eps {
+			if Distance(mesh.model.Points[id0], mesh.model.Points[id1]) < tol.eps() {
 				_ = et.Add(fmt.Errorf("triangle %d same points", i))
 				_ = et.Add(fmt.Errorf("point %d: %.13f", id0, mesh.model.Points[id0]))
 				_ = et.Add(fmt.Errorf("point %d: %.13f", id1, mesh.model.Points[id1]))
@@ -265,7 +296,7 @@ func (mesh Mesh) Check() (err error) {
 		if mesh.model.Triangles[i][0] == Removed {
 			continue
 		}
-		or := Orientation(
+		or := mesh.orientation(
 			mesh.model.Points[mesh.model.Triangles[i][0]],
 			mesh.model.Points[mesh.model.Triangles[i][1]],
 			mesh.model.Points[mesh.model.Triangles[i][2]],
@@ -371,13 +402,18 @@ func (mesh Mesh) Check() (err error) {
 	// TODO : 	}
 	// TODO : }
 
+	// for exact predicates all triangles are not degenerated by
+	// checking of clockwise orientation
 	for i := range mesh.model.Triangles {
+		if mesh.model.exact() {
+			break
+		}
 		if mesh.model.Triangles[i][0] == Removed {
 			continue
 		}
 		em := eTree.New(fmt.Sprintf("Segment check triangle %d", i))
 		for _, ind := range [][3]int{{0, 1, 2}, {2, 0, 1}, {1, 2, 0}} {
-			_, _, stB := PointLine(
+			_, _, stB := tol.PointLine(
 				mesh.model.Points[mesh.model.Triangles[i][ind[0]]],
 				mesh.model.Points[mesh.model.Triangles[i][ind[1]]],
 				mesh.model.Points[mesh.model.Triangles[i][ind[2]]],
@@ -424,7 +460,7 @@ func (mesh Mesh) Check() (err error) {
 					_ = em.Add(fmt.Errorf("case %v", (i1 == j0 && i0 == j1)))
 				}
 				if i0 != j0 && i1 != j0 {
-					_, _, stB := PointLine(
+					_, _, stB := tol.PointLine(
 						mesh.model.Points[j0],
 						mesh.model.Points[i0],
 						mesh.model.Points[i1],
@@ -441,7 +477,7 @@ func (mesh Mesh) Check() (err error) {
 					}
 				}
 				if i0 != j1 && i1 != j1 {
-					_, _, stB := PointLine(
+					_, _, stB := tol.PointLine(
 						mesh.model.Points[j1],
 						mesh.model.Points[i0],
 						mesh.model.Points[i1],
@@ -471,7 +507,7 @@ func (mesh Mesh) Check() (err error) {
 // Get add into Model all triangles from Mesh
 // Recommendation after `Get` : model.Intersection()
 func (model *Model) Get(mesh *Mesh) {
-	if Log {
+	if model.log() {
 		log.Printf("Get")
 	}
 	for _, tr := range mesh.model.Triangles {
@@ -487,31 +523,46 @@ func (model *Model) Get(mesh *Mesh) {
 	}
 }
 
-// Clockwise change all triangles to clockwise orientation
-func (mesh *Mesh) Clockwise() {
-	if Log {
+// Clockwise change all triangles to clockwise orientation.
+// For triangle with collinear points error `ErrCollinearTriangle` is
+// returned.
+func (mesh *Mesh) Clockwise() (err error) {
+	if mesh.model.log() {
 		log.Printf("Clockwise")
 	}
 	for i := range mesh.model.Triangles {
-		switch Orientation(
-			mesh.model.Points[mesh.model.Triangles[i][0]],
-			mesh.model.Points[mesh.model.Triangles[i][1]],
-			mesh.model.Points[mesh.model.Triangles[i][2]],
-		) {
+		t := mesh.model.Triangles[i]
+		if t[0] == Removed {
+			continue
+		}
+		ps := []Point{
+			mesh.model.Points[t[0]],
+			mesh.model.Points[t[1]],
+			mesh.model.Points[t[2]],
+		}
+		switch mesh.orientation(ps[0], ps[1], ps[2]) {
 		case CounterClockwisePoints:
 			mesh.Triangles[i][0], mesh.Triangles[i][2] =
 				mesh.Triangles[i][2], mesh.Triangles[i][0]
 			mesh.model.Triangles[i][1], mesh.model.Triangles[i][2] =
 				mesh.model.Triangles[i][2], mesh.model.Triangles[i][1]
 		case CollinearPoints:
-			panic(fmt.Errorf("collinear triangle: %#v", mesh.model))
+			err = &ElementError{
+				Err:    ErrCollinearTriangle,
+				Index:  i,
+				Points: []int{t[0], t[1], t[2]},
+				Coords: ps,
+			}
+			return
 		}
 	}
+	return
 }
 
 // AddPoint is add points with tag
 func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err error) {
-	if Log {
+	tol := mesh.model.tolerance()
+	if mesh.model.log() {
 		log.Printf("AddPoint: %.20e. tag = %d", p, tag)
 	}
 	defer func() {
@@ -519,14 +570,14 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 			et := eTree.New("AddPoint")
 			_ = et.Add(fmt.Errorf("add point with tag %d, coord: %.9f", tag, p))
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
-	if Debug {
+	if mesh.model.debug() {
 		if err = mesh.Check(); err != nil {
 			et := eTree.New("begin")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 			return
 		}
 	}
@@ -541,22 +592,71 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 		return
 	}
 
+	// triangle with point
+	near := Undefined
+	if len(triIndexes) == 0 {
+		near = mesh.locate(p)
+	} else if start := triIndexes[0]; !mesh.removed(start) {
+		near = mesh.walk(start, p)
+	}
+
 	// ignore points if on corner
-	for _, pt := range mesh.model.Points {
-		if Distance(p, pt) < Eps {
-			idp = add()
-			return
+	if 0 <= near {
+		// points of triangle and near triangles
+		for _, tr := range append(mesh.Triangles[near][:], near) {
+			if mesh.removed(tr) {
+				continue
+			}
+			for _, pt := range mesh.model.Triangles[tr][:3] {
+				if Distance(p, mesh.model.Points[pt]) < tol.eps() {
+					idp = add()
+					return
+				}
+			}
+		}
+	} else {
+		for _, pt := range mesh.model.Points {
+			if Distance(p, pt) < tol.eps() {
+				idp = add()
+				return
+			}
 		}
 	}
 
 	// add points on line
 	if tag != Movable {
-		for i, size := 0, len(mesh.model.Lines); i < size; i++ {
+		// lines are sides of triangles, so only lines on sides of
+		// located triangle and near triangles are checked
+		var lines []int
+		if 0 <= near {
+			for _, tr := range append(mesh.Triangles[near][:], near) {
+				if mesh.removed(tr) {
+					continue
+				}
+				t := mesh.model.Triangles[tr]
+				for j := 0; j < 3; j++ {
+					lines = append(lines, mesh.linesBetween(t[j], t[(j+1)%3])...)
+				}
+			}
+			sort.Ints(lines)
+			lines = slices.Compact(lines)
+		} else {
+			for i := range mesh.model.Lines {
+				lines = append(lines, i)
+			}
+		}
+		for _, i := range lines {
 			if mesh.model.Lines[i][2] == Removed {
 				continue
 			}
-			// TODO fast box checking
-			_, _, stB := PointLine(
+			if !inBox(p,
+				mesh.model.Points[mesh.model.Lines[i][0]],
+				mesh.model.Points[mesh.model.Lines[i][1]],
+				tol.eps(),
+			) {
+				continue
+			}
+			_, _, stB := tol.PointLine(
 				p,
 				mesh.model.Points[mesh.model.Lines[i][0]],
 				mesh.model.Points[mesh.model.Lines[i][1]],
@@ -581,7 +681,7 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 			}
 		}
 	}
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("check 0a: %v", err)
@@ -600,14 +700,14 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 		// split triangle
 		var res [][3]Point
 		var state int
-		res, state, err = TriangleSplitByPoint(
+		res, state, err = tol.TriangleSplitByPoint(
 			p,
 			mesh.model.Points[mesh.model.Triangles[i][0]],
 			mesh.model.Points[mesh.model.Triangles[i][1]],
 			mesh.model.Points[mesh.model.Triangles[i][2]],
 		)
 		if err != nil {
-			panic(err)
+			return
 		}
 		if len(res) == 0 {
 			return
@@ -639,7 +739,7 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 		if err != nil {
 			et := eTree.New("After repairTriangles")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 			return
 		}
 
@@ -650,40 +750,70 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 			et := eTree.New("Delanay update")
 			_ = et.Add(err)
 			_ = et.Add(fmt.Errorf("len of res: %d", len(res)))
-			err = et
+			err = errorTree{et}
 			return
 		}
 		// TODO : add to delanay flip linked list
 		return true, nil
 	}
 
-	if len(triIndexes) == 0 {
-		triIndexes = make([]int, len(mesh.model.Triangles))
+	all := func() []int {
+		indexes := make([]int, len(mesh.model.Triangles))
 		for i := range mesh.model.Triangles {
-			triIndexes[i] = i
+			indexes[i] = i
 		}
+		return indexes
 	}
 	counter := 0
-	for _, tri := range triIndexes {
-		var added bool
-		added, err = addInTriangle(tri)
-		if err != nil {
-			et := eTree.New("triangle indexes")
-			_ = et.Add(err)
-			if 20 < len(triIndexes) {
-				_ = et.Add(fmt.Errorf("list triIndexes: %v ... more %d", triIndexes[:10], len(triIndexes)))
-			} else {
-				_ = et.Add(fmt.Errorf("list triIndexes: %v", triIndexes))
+	insert := func(triIndexes []int, located bool) (err error) {
+		for _, tri := range triIndexes {
+			if located && 0 < counter {
+				// point is added in located triangle
+				break
+			}
+			var added bool
+			added, err = addInTriangle(tri)
+			if err != nil {
+				et := eTree.New("triangle indexes")
+				_ = et.Add(err)
+				if 20 < len(triIndexes) {
+					_ = et.Add(fmt.Errorf("list triIndexes: %v ... more %d", triIndexes[:10], len(triIndexes)))
+				} else {
+					_ = et.Add(fmt.Errorf("list triIndexes: %v", triIndexes))
+				}
+				err = errorTree{et}
+				return
+			}
+			if added {
+				counter++
 			}
-			err = et
-			return
 		}
-		if added {
-			counter++
+		return
+	}
+	switch {
+	case 0 < len(triIndexes):
+		err = insert(triIndexes, false)
+	case 0 <= near:
+		// located triangle and near triangles for point on side
+		var list []int
+		for _, tr := range append(mesh.Triangles[near][:], near) {
+			if !mesh.removed(tr) {
+				list = append(list, tr)
+			}
+		}
+		sort.Ints(list)
+		if err = insert(list, true); err == nil && counter == 0 {
+			// for case of fail all triangles are checked
+			err = insert(all(), false)
 		}
+	default:
+		err = insert(all(), false)
+	}
+	if err != nil {
+		return
 	}
 	if counter == 0 {
-		err = fmt.Errorf("not found triangle: %v", idp)
+		err = &ElementError{Err: ErrPointOutside, Index: -1, Coords: []Point{p}}
 		return
 	}
 	if 1 < counter {
@@ -691,7 +821,7 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 		return
 	}
 	// outside of triangles or on corners
-	if Debug {
+	if mesh.model.debug() {
 		if errc := mesh.Check(); err != nil {
 			err = eTree.New("Check at the end").Add(errc)
 		}
@@ -701,20 +831,20 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 			}
 			same := false
 			for i := 0; i < 3; i++ {
-				if SamePoints(mesh.model.Points[idp], mesh.model.Points[tps[i]]) {
+				if tol.SamePoints(mesh.model.Points[idp], mesh.model.Points[tps[i]]) {
 					same = true
 				}
 			}
 			if same {
 				continue
 			}
-			res, lineIntersect, err := TriangleSplitByPoint(
+			res, lineIntersect, errs := tol.TriangleSplitByPoint(
 				mesh.model.Points[idp],
 				mesh.model.Points[tps[0]],
 				mesh.model.Points[tps[1]],
 				mesh.model.Points[tps[2]],
 			)
-			if err != nil {
+			if errs != nil {
 				continue
 			}
 			if len(res) == 0 {
@@ -728,7 +858,7 @@ func (mesh *Mesh) AddPoint(p Point, tag int, triIndexes ...int) (idp int, err er
 				fmt.Errorf("line intersect: %v", lineIntersect),
 				fmt.Errorf("res: %v", res),
 			)
-			panic(err)
+			return
 		}
 	}
 	return
@@ -754,7 +884,8 @@ func (mesh *Mesh) shiftTriangle(i int) {
 //	200 - point on line with 1 boundary triangle
 //	300 - point in triangle
 func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int, err error) {
-	if Log {
+	tol := mesh.model.tolerance()
+	if mesh.model.log() {
 		log.Printf("repairTriangles: ap=%d state = %d", ap, state)
 	}
 	defer func() {
@@ -764,10 +895,10 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 			_ = et.Add(fmt.Errorf("state{%v}", state))
 			_ = et.Add(fmt.Errorf("remove{%v}", rt))
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
-	if Debug {
+	if mesh.model.debug() {
 		if err = mesh.Check(); err != nil {
 			err = fmt.Errorf("check 0: %v", err)
 			return
@@ -795,7 +926,7 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 	}
 	var chains []chain
 	tc := [2]int{Undefined, Undefined} // index of corner triangle
-	if Debug {
+	if mesh.model.debug() {
 		if tc[0] != Undefined || tc[1] != Undefined {
 			panic("not set default values")
 		}
@@ -821,49 +952,49 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 			// repair triangles sides
 			return mesh.repairTriangles(ap, rt, state)
 		}
-		if Debug {
+		if mesh.model.debug() {
 			if mesh.model.Triangles[rt[0]][0] != mesh.model.Triangles[rt[1]][1] &&
 				mesh.model.Triangles[rt[0]][1] != mesh.model.Triangles[rt[1]][0] {
 				err = fmt.Errorf("not valid rotation")
 				return
 			}
-			_, _, stB001 := PointLine(
+			_, _, stB001 := tol.PointLine(
 				mesh.model.Points[ap],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][0]],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][1]],
 			)
-			_, _, stB012 := PointLine(
+			_, _, stB012 := tol.PointLine(
 				mesh.model.Points[ap],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][1]],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][2]],
 			)
-			_, _, stB020 := PointLine(
+			_, _, stB020 := tol.PointLine(
 				mesh.model.Points[ap],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][2]],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][0]],
 			)
-			_, _, stB0012 := PointLine(
+			_, _, stB0012 := tol.PointLine(
 				mesh.model.Points[mesh.model.Triangles[rt[0]][0]],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][1]],
 				mesh.model.Points[mesh.model.Triangles[rt[0]][2]],
 			)
 
-			_, _, stB101 := PointLine(
+			_, _, stB101 := tol.PointLine(
 				mesh.model.Points[ap],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][0]],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][1]],
 			)
-			_, _, stB112 := PointLine(
+			_, _, stB112 := tol.PointLine(
 				mesh.model.Points[ap],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][1]],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][2]],
 			)
-			_, _, stB120 := PointLine(
+			_, _, stB120 := tol.PointLine(
 				mesh.model.Points[ap],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][2]],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][0]],
 			)
-			_, _, stB1012 := PointLine(
+			_, _, stB1012 := tol.PointLine(
 				mesh.model.Points[mesh.model.Triangles[rt[1]][0]],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][1]],
 				mesh.model.Points[mesh.model.Triangles[rt[1]][2]],
@@ -890,14 +1021,14 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 				_ = et.Add(fmt.Errorf("%v\n%v", stB112.Has(OnSegment), stB112))
 				_ = et.Add(fmt.Errorf("%v\n%v", stB120.Has(OnSegment), stB120))
 				_ = et.Add(fmt.Errorf("%v", stB1012))
-				err = et
+				err = errorTree{et}
 				return
 			}
 		}
 		// debug: point in not on line
-		if Debug {
+		if mesh.model.debug() {
 			for k := 0; k < 2; k++ {
-				_, _, stB := PointLine(
+				_, _, stB := tol.PointLine(
 					mesh.model.Points[ap],
 					mesh.model.Points[mesh.model.Triangles[rt[k]][0]],
 					mesh.model.Points[mesh.model.Triangles[rt[k]][1]],
@@ -906,21 +1037,21 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 					et := eTree.New("State100")
 					_ = et.Add(fmt.Errorf("point is not on line %v", k))
 
-					if _, _, stB := PointLine(
+					if _, _, stB := tol.PointLine(
 						mesh.model.Points[ap],
 						mesh.model.Points[mesh.model.Triangles[rt[k]][0]],
 						mesh.model.Points[mesh.model.Triangles[rt[k]][1]],
 					); stB.Has(OnSegment) {
 						_ = et.Add(fmt.Errorf("on segment 0 1"))
 					}
-					if _, _, stB := PointLine(
+					if _, _, stB := tol.PointLine(
 						mesh.model.Points[ap],
 						mesh.model.Points[mesh.model.Triangles[rt[k]][1]],
 						mesh.model.Points[mesh.model.Triangles[rt[k]][2]],
 					); stB.Has(OnSegment) {
 						_ = et.Add(fmt.Errorf("on segment 1 2"))
 					}
-					if _, _, stB := PointLine(
+					if _, _, stB := tol.PointLine(
 						mesh.model.Points[ap],
 						mesh.model.Points[mesh.model.Triangles[rt[k]][2]],
 						mesh.model.Points[mesh.model.Triangles[rt[k]][0]],
@@ -928,7 +1059,7 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 						_ = et.Add(fmt.Errorf("on segment 2 0"))
 					}
 
-					err = et
+					err = errorTree{et}
 					return
 				}
 			}
@@ -1068,17 +1199,19 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 
 	// create triangles
 	for i := range chains {
-		mesh.model.AddTriangle(
-			mesh.model.Points[chains[i].from],
-			mesh.model.Points[chains[i].to],
-			mesh.model.Points[ap],
-			// by default used tag of first triangle
-			mesh.model.Triangles[rt[0]][0],
-		)
-		tr := [3]int{Undefined, Undefined, Undefined}
 		if chains[i].before == Undefined {
 			panic("undefined")
 		}
+		// points of triangle are exist and triangle with new point
+		// is not exist, so triangle is added without checking
+		mesh.model.Triangles = append(mesh.model.Triangles, [4]int{
+			chains[i].from,
+			chains[i].to,
+			ap,
+			// tag of removed triangle
+			mesh.model.Triangles[chains[i].before][3],
+		})
+		tr := [3]int{Undefined, Undefined, Undefined}
 
 		tr[0] = chains[i].out
 		mesh.swap(chains[i].out, chains[i].before, chains[i].in)
@@ -1106,7 +1239,7 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 		mesh.Triangles[rem][2] = Removed
 	}
 
-	if Debug {
+	if mesh.model.debug() {
 		if err = mesh.Check(); err != nil {
 			et := eTree.New("Check 1")
 			_ = et.Add(err)
@@ -1127,7 +1260,7 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 						mesh.model.Points[ap],
 						mesh.model.Points[chains[i].to],
 					),
-					Orientation(
+					tol.Orientation(
 						mesh.model.Points[chains[i].from],
 						mesh.model.Points[chains[i].to],
 						mesh.model.Points[ap],
@@ -1144,7 +1277,7 @@ func (mesh *Mesh) repairTriangles(ap int, rt []int, state int) (updateTr []int,
 				_ = et.Add(fmt.Errorf("remove triangle %d", r))
 			}
 			_ = et.Add(fmt.Errorf("corner triangle %d", tc))
-			err = et
+			err = errorTree{et}
 			return
 		}
 	}
@@ -1169,14 +1302,14 @@ func (mesh *Mesh) swap(elem, from, to int) {
 
 // TODO delanay only for some triangles, if list empty then for  all triangles
 func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
-	if Log {
+	if mesh.model.log() {
 		log.Printf("Delanay: amount %d", len(triIndexes))
 	}
 	defer func() {
 		if err != nil {
 			et := eTree.New("Delanay")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
 	defer func() {
@@ -1229,7 +1362,7 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 		// is point in circle
 		// Problem : for long triangle - possible triangle, but
 		// not possible for arc
-		if !PointInCircle(
+		if !mesh.inCircle(
 			mesh.model.Points[mesh.model.Triangles[neartr][2]],
 			[3]Point{
 				mesh.model.Points[mesh.model.Triangles[tr][0]],
@@ -1241,20 +1374,11 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 		}
 
 		// flip only if middle side is not fixed
-		{
-			idp0 := mesh.model.AddPoint(mesh.model.Points[mesh.model.Triangles[neartr][0]])
-			idp1 := mesh.model.AddPoint(mesh.model.Points[mesh.model.Triangles[neartr][1]])
-			for _, line := range mesh.model.Lines {
-				if line[0] != idp0 && line[0] != idp1 {
-					continue
-				}
-				if line[1] != idp0 && line[1] != idp1 {
-					continue
-				}
-				if line[2] == Fixed {
-					return
-				}
-			}
+		if mesh.fixedLine(
+			mesh.model.Triangles[neartr][0],
+			mesh.model.Triangles[neartr][1],
+		) {
+			return
 		}
 
 		// rotate triangle tr
@@ -1269,7 +1393,7 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 			mesh.shiftTriangle(tr)
 		}
 
-		if Debug {
+		if mesh.model.debug() {
 			if mesh.model.Triangles[tr][0] != mesh.model.Triangles[neartr][1] ||
 				mesh.model.Triangles[tr][1] != mesh.model.Triangles[neartr][0] {
 				err = fmt.Errorf("not valid input")
@@ -1281,19 +1405,19 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 		flip = true
 
 		//corner case:
-		if ClockwisePoints != Orientation(
+		if ClockwisePoints != mesh.orientation(
 			mesh.model.Points[mesh.model.Triangles[tr][0]],
 			mesh.model.Points[mesh.model.Triangles[tr][1]],
 			mesh.model.Points[mesh.model.Triangles[tr][2]],
-		) || ClockwisePoints != Orientation(
+		) || ClockwisePoints != mesh.orientation(
 			mesh.model.Points[mesh.model.Triangles[neartr][0]],
 			mesh.model.Points[mesh.model.Triangles[neartr][1]],
 			mesh.model.Points[mesh.model.Triangles[neartr][2]],
-		) || ClockwisePoints != Orientation(
+		) || ClockwisePoints != mesh.orientation(
 			mesh.model.Points[mesh.model.Triangles[tr][0]],
 			mesh.model.Points[mesh.model.Triangles[neartr][2]],
 			mesh.model.Points[mesh.model.Triangles[tr][2]],
-		) || ClockwisePoints != Orientation(
+		) || ClockwisePoints != mesh.orientation(
 			mesh.model.Points[mesh.model.Triangles[neartr][0]],
 			mesh.model.Points[mesh.model.Triangles[tr][2]],
 			mesh.model.Points[mesh.model.Triangles[neartr][2]],
@@ -1330,7 +1454,7 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 			mesh.swap(red[0], tr, neartr)
 			mesh.swap(blu[0], neartr, tr)
 		}
-		if Debug {
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				et := eTree.New("after delanay")
@@ -1353,13 +1477,13 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 					"near triangles of %d is %v", neartr, mesh.Triangles[neartr]))
 
 				_ = et.Add(err)
-				err = et
+				err = errorTree{et}
 				return
 			}
 		}
 		return
 	}
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("input: %v", err)
@@ -1367,38 +1491,48 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 		}
 	}
 
-	// initialize
-	{
-		size := len(mesh.model.Triangles)
-		if len(mesh.templorary.ignore) < size {
-			mesh.templorary.ignore = make([]bool, size*2)
+	// list of triangles
+	var list []int
+	if 0 < len(triIndexes) {
+		list = make([]int, 0, len(triIndexes))
+		for _, index := range triIndexes {
+			if 0 <= index {
+				list = append(list, index)
+			}
+		}
+		sort.Ints(list)
+		// remove duplicates
+		for i := len(list) - 1; 0 < i; i-- {
+			if list[i] == list[i-1] {
+				list = append(list[:i], list[i+1:]...)
+			}
 		}
 	}
-	ignore := &mesh.templorary.ignore
 
 	// loop of triangles
 	for iter := 0; ; iter++ {
 		counter := 0
-
-		// reset values
-		for i := range *ignore {
-			(*ignore)[i] = false
-		}
-
-		for _, index := range triIndexes {
-			if index < 0 {
-				continue
+		if len(triIndexes) == 0 {
+			if err = mesh.progress.report("Delanay", iter, 0); err != nil {
+				return
 			}
-			(*ignore)[index] = true
 		}
 
-		for tr := range mesh.model.Triangles {
-			if 0 < len(triIndexes) && !(*ignore)[tr] {
-				continue
+		size := len(mesh.model.Triangles)
+		if 0 < len(triIndexes) {
+			size = len(list)
+		}
+		for i := 0; i < size; i++ {
+			tr := i
+			if 0 < len(triIndexes) {
+				tr = list[i]
 			}
 			if mesh.model.Triangles[tr][0] == Removed {
 				continue
 			}
+			if err = mesh.progress.done(); err != nil {
+				return
+			}
 			var flip bool
 			for side := 0; side < 3; side++ {
 				flip, err = delanay(tr, side)
@@ -1407,12 +1541,12 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 				}
 				if flip {
 					counter++
-					if Debug {
+					if mesh.model.debug() {
 						err = mesh.Check()
 						if err != nil {
 							et := eTree.New("In loop")
 							_ = et.Add(err)
-							err = et
+							err = errorTree{et}
 							return
 						}
 					}
@@ -1420,7 +1554,7 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 				}
 			}
 		}
-		if Debug {
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				err = fmt.Errorf("end of loop: %v", err)
@@ -1435,7 +1569,7 @@ func (mesh *Mesh) Delanay(triIndexes ...int) (err error) {
 			return
 		}
 	}
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("end: %v", err)
@@ -1479,20 +1613,21 @@ func (mesh *Mesh) SetMaterial(p Point, material int) (err error) {
 
 // GetMaterials return materials for each point
 func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
-	if Log {
+	tol := mesh.model.tolerance()
+	if mesh.model.log() {
 		log.Printf("GetMaterials")
 	}
 	defer func() {
 		if err != nil {
 			et := eTree.New("GetMaterials")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
 
 	for _, p := range ps {
 		for i := range mesh.model.Points {
-			if Eps < Distance(p, mesh.model.Points[i]) {
+			if tol.eps() < Distance(p, mesh.model.Points[i]) {
 				continue
 			}
 			// point on triangulation point
@@ -1517,7 +1652,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 				}
 			}
 			materials = append(materials, mat)
-			if Log {
+			if mesh.model.log() {
 				log.Printf("GetMaterials point in point: %v", materials)
 			}
 		}
@@ -1530,7 +1665,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 
 			var res [][3]Point
 			var lineIntersect int
-			res, lineIntersect, err = TriangleSplitByPoint(p,
+			res, lineIntersect, err = tol.TriangleSplitByPoint(p,
 				mesh.model.Points[tri[0]],
 				mesh.model.Points[tri[1]],
 				mesh.model.Points[tri[2]],
@@ -1541,7 +1676,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 			switch len(res) {
 			case 3:
 				materials = append(materials, tri[3])
-				if Log {
+				if mesh.model.log() {
 					log.Printf("GetMaterials triangle %d %v in triangle: %v",
 						it, tri, materials)
 				}
@@ -1557,7 +1692,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 				}
 				if mat[1] == Boundary {
 					materials = append(materials, mat[0])
-					if Log {
+					if mesh.model.log() {
 						log.Printf("GetMaterials triangle %d %v on edge with boundary: %v",
 							it, tri, materials)
 					}
@@ -1568,7 +1703,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 					return
 				}
 				materials = append(materials, mat[0])
-				if Log {
+				if mesh.model.log() {
 					log.Printf("GetMaterials triangle %d %v and %d %v on edge: %v",
 						it, tri,
 						mesh.Triangles[it][j], mesh.model.Triangles[mesh.Triangles[it][j]],
@@ -1577,7 +1712,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 			}
 		}
 
-		if Log {
+		if mesh.model.log() {
 			box := func(ps ...Point) (xmin, xmax, ymax, ymin float64) {
 				xmin = +math.MaxFloat64
 				xmax = -math.MaxFloat64
@@ -1616,7 +1751,7 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 	}
 
 	// possible point is outside of triangulation
-	err = fmt.Errorf("point %.3f is outside of triangulation", ps)
+	err = &ElementError{Err: ErrPointOutside, Index: -1, Coords: ps}
 	return
 }
 
@@ -1625,14 +1760,14 @@ func (mesh *Mesh) GetMaterials(ps ...Point) (materials []int, err error) {
 // If points slice is not empty, then return material mark number for
 // each point
 func (mesh *Mesh) Materials() (err error) {
-	if Log {
+	if mesh.model.log() {
 		log.Printf("Materials")
 	}
 	defer func() {
 		if err != nil {
 			et := eTree.New("Materials")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
 
@@ -1647,7 +1782,7 @@ func (mesh *Mesh) Materials() (err error) {
 
 	var mark func(from, to, counter int) error
 	mark = func(from, to, counter int) (err error) {
-		if Debug {
+		if mesh.model.debug() {
 			if to == Removed {
 				err = fmt.Errorf("triangle `to` is removed")
 				return
@@ -1672,23 +1807,15 @@ func (mesh *Mesh) Materials() (err error) {
 				uniq = append(uniq, points[i])
 			}
 		}
-		if Debug {
+		if mesh.model.debug() {
 			if len(uniq) != 2 {
 				err = fmt.Errorf("not 2 points: %v. %v", uniq, points)
 				return
 			}
 		}
 		// have line and fixed
-		for _, line := range mesh.model.Lines {
-			if line[0] != uniq[0] && line[0] != uniq[1] {
-				continue
-			}
-			if line[1] != uniq[0] && line[1] != uniq[1] {
-				continue
-			}
-			if line[2] == Fixed {
-				return
-			}
+		if mesh.fixedLine(uniq[0], uniq[1]) {
+			return
 		}
 		if mesh.model.Triangles[to][3] != Undefined {
 			err = fmt.Errorf("double mark: %v %v",
@@ -1739,7 +1866,8 @@ func (mesh *Mesh) Materials() (err error) {
 
 // Smooth move all movable point by average distance
 func (mesh *Mesh) Smooth(pts ...int) (err error) {
-	if Log {
+	tol := mesh.model.tolerance()
+	if mesh.model.log() {
 		log.Printf("Smooth")
 	}
 	defer func() {
@@ -1747,7 +1875,7 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 			et := eTree.New("Smooth")
 			_ = et.Add(fmt.Errorf("input point list: %v", pts))
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
 	// for acceptable movable points calculate all side distances from that
@@ -1762,7 +1890,9 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 	}
 	var store []Store
 
-	if len(pts) == 0 {
+	// progress is reported only for all points
+	all := len(pts) == 0
+	if all {
 		pts = make([]int, len(mesh.model.Points))
 		for i := range pts {
 			pts[i] = i
@@ -1774,39 +1904,47 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 		return
 	}
 
+	// points on fixed lines and triangles of points
+	onLine := make([]bool, len(mesh.model.Points))
+	for _, line := range mesh.model.Lines {
+		if line[2] == Fixed {
+			onLine[line[0]] = true
+			onLine[line[1]] = true
+		}
+	}
+	triangles := make([][]int, len(mesh.model.Points))
+	for index, tri := range mesh.model.Triangles {
+		if tri[0] == Removed {
+			continue
+		}
+		for _, p := range tri[:3] {
+			triangles[p] = append(triangles[p], index)
+		}
+	}
+
 	// create list of all movable points
 	nearPoints := make([]int, 0, 20)
-	nearTriangles := make([]int, 0, 20)
-	for _, p := range pts {
+	for i, p := range pts {
+		if all {
+			if err = mesh.progress.report("Smooth", i, len(pts)); err != nil {
+				return
+			}
+		}
 		if mesh.Points[p] != Movable {
 			continue
 		}
 		if mesh.Points[p] == Fixed {
 			continue
 		}
-		{ // point is not on fixed line
-			fix := false
-			for _, line := range mesh.model.Lines {
-				if line[0] != p && line[1] != p {
-					continue
-				}
-				if line[2] == Fixed {
-					fix = true
-				}
-			}
-			if fix {
-				continue
-			}
+		// point is not on fixed line
+		if onLine[p] {
+			continue
 		}
 		// find near triangles
 		nearPoints = nearPoints[:0]
-		nearTriangles = nearTriangles[:0]
-		for index, tri := range mesh.model.Triangles {
-			if p != tri[0] && p != tri[1] && p != tri[2] {
-				continue
-			}
-			nearPoints = append(nearPoints, tri[0:3]...)
-			nearTriangles = append(nearTriangles, index)
+		nearTriangles := triangles[p]
+		for _, index := range nearTriangles {
+			nearPoints = append(nearPoints, mesh.model.Triangles[index][0:3]...)
 		}
 		{ // point is not on boundary triangle side
 			onBoundary := false
@@ -1869,7 +2007,10 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 	max := 1.0
 	iter := 0
 
-	for ; iter < 10 && Eps < max; iter++ {
+	for ; iter < 10 && tol.eps() < max; iter++ {
+		if err = mesh.progress.done(); err != nil {
+			return
+		}
 		max = 0.0
 		for _, st := range store {
 			var x, y float64
@@ -1884,11 +2025,10 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 				X: mesh.model.Points[st.index].X,
 				Y: mesh.model.Points[st.index].Y,
 			}
-			mesh.model.Points[st.index].X = x
-			mesh.model.Points[st.index].Y = y
+			mesh.model.setPoint(st.index, Point{X: x, Y: y})
 			isValid := true
 			for _, index := range st.nearTriangles {
-				if ClockwisePoints != Orientation(
+				if ClockwisePoints != mesh.orientation(
 					mesh.model.Points[mesh.model.Triangles[index][0]],
 					mesh.model.Points[mesh.model.Triangles[index][1]],
 					mesh.model.Points[mesh.model.Triangles[index][2]],
@@ -1898,7 +2038,7 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 				}
 			}
 			if !isValid {
-				mesh.model.Points[st.index] = last
+				mesh.model.setPoint(st.index, last)
 				//store = append(store[:indexStore], store[indexStore+1:]...)
 				continue
 			}
@@ -1906,7 +2046,7 @@ func (mesh *Mesh) Smooth(pts ...int) (err error) {
 		}
 	}
 	// typically amount iter is 1
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("end of func: %v", err)
@@ -1938,18 +2078,25 @@ func (mesh *Mesh) Split(factor float64) (err error) {
 //	}
 //
 // If factorFunc is not valid, then splitting will be infinite.
+// For cancellation of splitting see `Mesh.SplitFuncContext`.
+//
+// For few workers, see `Config`, factorFunc is calculated in parallel
+// and must be safe for concurrent use. Points are added in same order
+// as for one worker.
 func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
-	if Log {
+	tol := mesh.model.tolerance()
+	workers := mesh.model.workers()
+	if mesh.model.log() {
 		log.Printf("Split")
 	}
 	defer func() {
 		if err != nil {
 			et := eTree.New("Split")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("input: %v", err)
@@ -1961,11 +2108,17 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 	var chains []Point
 
 	counter := 0
+	stage := ""
+	added := 0 // amount of all added points
 	addpoint := func(p1, p2 Point, tag int, triIndexes ...int) (err error) {
 		if !factorFunc(p1, p2) {
 			return
 		}
+		if err = mesh.progress.report(stage, added, 0); err != nil {
+			return
+		}
 		counter++
+		added++
 		// add middle point
 		mid := MiddlePoint(p1, p2)
 		// add all points of model
@@ -1976,16 +2129,16 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 				_ = et.Add(fmt.Errorf("mid   point: %.9f", mid))
 				_ = et.Add(fmt.Errorf("right point: %.9f", p2))
 				_ = et.Add(err)
-				err = et
+				err = errorTree{et}
 			}
 		}()
-		if Debug {
-			if Orientation(p1, mid, p2) != CollinearPoints {
+		if mesh.model.debug() {
+			if tol.Orientation(p1, mid, p2) != CollinearPoints {
 				et := eTree.New("MiddlePoint")
 				_ = et.Add(fmt.Errorf("p1   = %.10f", p1))
 				_ = et.Add(fmt.Errorf("mid  = %.10f", mid))
 				_ = et.Add(fmt.Errorf("p2   = %.10f", p2))
-				err = et
+				err = errorTree{et}
 				return
 			}
 		}
@@ -1996,11 +2149,11 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 		if tag == Movable {
 			err = mesh.Smooth(idp)
 		}
-		if Debug {
+		if mesh.model.debug() {
 			counter := 0
 			var ch Point
 			for i := range chains {
-				if dist := Distance(chains[i], mid); dist < Eps {
+				if dist := Distance(chains[i], mid); dist < tol.eps() {
 					ch = chains[i]
 					counter++
 				}
@@ -2015,8 +2168,9 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 		return
 	}
 
-	iteration := func(do func() error) (err error) {
-		if Debug {
+	iteration := func(name string, do func() error) (err error) {
+		stage = name
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				err = fmt.Errorf("begin in loop: %v", err)
@@ -2037,7 +2191,7 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 				break
 			}
 		}
-		if Debug {
+		if mesh.model.debug() {
 			err = mesh.Check()
 			if err != nil {
 				err = fmt.Errorf("begin in loop: %v", err)
@@ -2047,10 +2201,78 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 		return nil
 	}
 
-	if err = iteration(func() (err error) {
+	// splitEdge is precalculated splitting edge of triangle or line.
+	// Value is used only if points and flags are not changed.
+	type splitEdge struct {
+		valid bool
+		ps    [3]Point
+		flags [3]int
+		edge  int // index of splitting edge or -1
+	}
+	// precalculate return function of splitting edge for elements.
+	// Splitting edges of existed elements are calculated by workers.
+	precalculate := func(
+		size int,
+		state func(i int) (s splitEdge),
+		choose func(s splitEdge) int,
+	) func(i int) int {
+		var pre []splitEdge
+		if 1 < workers {
+			pre = make([]splitEdge, size)
+			parallel(workers, size, func(i int) {
+				if s := state(i); s.valid {
+					s.edge = choose(s)
+					pre[i] = s
+				}
+			})
+		}
+		return func(i int) int {
+			s := state(i)
+			if !s.valid {
+				return -1
+			}
+			if i < len(pre) && pre[i].valid && pre[i].ps == s.ps && pre[i].flags == s.flags {
+				return pre[i].edge
+			}
+			return choose(s)
+		}
+	}
+	// state of triangle
+	triangle := func(i int) (s splitEdge) {
+		t := mesh.model.Triangles[i]
+		if t[0] == Removed {
+			return
+		}
+		s.valid = true
+		s.ps = [3]Point{
+			mesh.model.Points[t[0]],
+			mesh.model.Points[t[1]],
+			mesh.model.Points[t[2]],
+		}
+		s.flags = mesh.Triangles[i]
+		return
+	}
+
+	if err = iteration("Split: fixed lines", func() (err error) {
 		// split fixed lines
-		for _, line := range mesh.model.Lines {
+		lines := mesh.model.Lines
+		split := precalculate(len(lines), func(i int) (s splitEdge) {
+			line := lines[i]
 			if line[2] == Removed {
+				return
+			}
+			s.valid = true
+			s.ps[0] = mesh.model.Points[line[0]]
+			s.ps[1] = mesh.model.Points[line[1]]
+			return
+		}, func(s splitEdge) int {
+			if factorFunc(s.ps[0], s.ps[1]) {
+				return 0
+			}
+			return -1
+		})
+		for i, line := range lines {
+			if split(i) < 0 {
 				continue
 			}
 			err = addpoint(
@@ -2061,7 +2283,7 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 			if err != nil {
 				et := eTree.New("split fixed lines")
 				_ = et.Add(err)
-				err = et
+				err = errorTree{et}
 				return
 			}
 		}
@@ -2070,8 +2292,16 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 		return
 	}
 
-	if err = iteration(func() (err error) {
+	if err = iteration("Split: boundary edges", func() (err error) {
 		// split big triangle edges with boundary
+		split := precalculate(len(mesh.model.Triangles), triangle, func(s splitEdge) int {
+			for e := 0; e < 3; e++ {
+				if s.flags[e] == Boundary && factorFunc(s.ps[e], s.ps[(e+1)%3]) {
+					return e
+				}
+			}
+			return -1
+		})
 		for i := range mesh.model.Triangles {
 			if mesh.model.Triangles[i][0] == Removed {
 				continue
@@ -2084,12 +2314,12 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 			p0 := mesh.model.Points[t[0]]
 			p1 := mesh.model.Points[t[1]]
 			p2 := mesh.model.Points[t[2]]
-			switch {
-			case mesh.Triangles[i][0] == Boundary && factorFunc(p0, p1):
+			switch split(i) {
+			case 0:
 				err = addpoint(p0, p1, Fixed, i)
-			case mesh.Triangles[i][1] == Boundary && factorFunc(p1, p2):
+			case 1:
 				err = addpoint(p1, p2, Fixed, i)
-			case mesh.Triangles[i][2] == Boundary && factorFunc(p2, p0):
+			case 2:
 				err = addpoint(p2, p0, Fixed, i)
 			}
 			if err != nil {
@@ -2100,7 +2330,7 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 				_ = et.Add(fmt.Errorf("points :%.8f", p1))
 				_ = et.Add(fmt.Errorf("points :%.8f", p2))
 				_ = et.Add(err)
-				err = et
+				err = errorTree{et}
 				return
 			}
 		}
@@ -2109,8 +2339,23 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 		return
 	}
 
-	if err = iteration(func() (err error) {
+	if err = iteration("Split: triangle edges", func() (err error) {
 		// split big triangle edges
+		split := precalculate(len(mesh.model.Triangles), triangle, func(s splitEdge) int {
+			d01 := Distance(s.ps[0], s.ps[1])
+			d12 := Distance(s.ps[1], s.ps[2])
+			d20 := Distance(s.ps[2], s.ps[0])
+			maxd := math.Max(math.Max(d01, d12), d20)
+			switch {
+			case maxd == d12 && factorFunc(s.ps[1], s.ps[2]):
+				return 1
+			case maxd == d01 && factorFunc(s.ps[0], s.ps[1]):
+				return 0
+			case maxd == d20 && factorFunc(s.ps[2], s.ps[0]):
+				return 2
+			}
+			return -1
+		})
 		for i := range mesh.model.Triangles {
 			if mesh.model.Triangles[i][0] == Removed {
 				continue
@@ -2123,19 +2368,18 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 			p0 := mesh.model.Points[t[0]]
 			p1 := mesh.model.Points[t[1]]
 			p2 := mesh.model.Points[t[2]]
-			d01 := Distance(p0, p1)
-			d12 := Distance(p1, p2)
-			d20 := Distance(p2, p0)
-			maxd := math.Max(math.Max(d01, d12), d20)
-			switch {
-			case maxd == d12 && factorFunc(p1, p2):
+			switch split(i) {
+			case 1:
 				err = addpoint(p1, p2, Movable, i)
-			case maxd == d01 && factorFunc(p0, p1):
+			case 0:
 				err = addpoint(p0, p1, Movable, i)
-			case maxd == d20 && factorFunc(p2, p0):
+			case 2:
 				err = addpoint(p2, p0, Movable, i)
 			}
 			if err != nil {
+				d01 := Distance(p0, p1)
+				d12 := Distance(p1, p2)
+				d20 := Distance(p2, p0)
 				et := eTree.New("split big triangle edge")
 				_ = et.Add(fmt.Errorf("counter: %d", counter))
 				_ = et.Add(fmt.Errorf("triangle: %d", i))
@@ -2146,7 +2390,7 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 				_ = et.Add(fmt.Errorf("distance 12 :%.8f", d12))
 				_ = et.Add(fmt.Errorf("distance 20 :%.8f", d20))
 				_ = et.Add(err)
-				err = et
+				err = errorTree{et}
 				return
 			}
 		}
@@ -2169,7 +2413,7 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 		return
 	}
 
-	if Debug {
+	if mesh.model.debug() {
 		err = mesh.Check()
 		if err != nil {
 			err = fmt.Errorf("at the end: %v", err)
@@ -2182,18 +2426,19 @@ func (mesh *Mesh) SplitFunc(factorFunc func(p1, p2 Point) bool) (err error) {
 
 // AddLine is add line in triangulation with tag
 func (mesh *Mesh) AddLine(inp1, inp2 Point) (err error) {
-	if Log {
+	tol := mesh.model.tolerance()
+	if mesh.model.log() {
 		log.Printf("AddLine")
 	}
 	defer func() {
 		if err != nil {
 			et := eTree.New("AddLine")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 		}
 	}()
 
-	if SamePoints(inp1, inp2) {
+	if tol.SamePoints(inp1, inp2) {
 		err = fmt.Errorf("AddLine: points are same")
 		return
 	}
@@ -2205,7 +2450,7 @@ func (mesh *Mesh) AddLine(inp1, inp2 Point) (err error) {
 		if err != nil {
 			et := eTree.New("add p1")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 			return
 		}
 		var idp2 int
@@ -2213,17 +2458,29 @@ func (mesh *Mesh) AddLine(inp1, inp2 Point) (err error) {
 		if err != nil {
 			et := eTree.New("add p2")
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 			return
 		}
-		// put fixed lines
-		mesh.model.AddLine(inp1, inp2, Fixed)
 		// triangle edges on line
 		list = []int{idp1, idp2}
 	}
+	// fixed lines are added by triangle edges, because lines are
+	// searched only on sides of triangles
+	defer func() {
+		if err != nil {
+			return
+		}
+		for i := 1; i < len(list); i++ {
+			mesh.model.AddLine(
+				mesh.model.Points[list[i-1]],
+				mesh.model.Points[list[i]],
+				Fixed,
+			)
+		}
+	}()
 	// triangle edges on line
 again:
-	if Debug {
+	if mesh.model.debug() {
 		for _, idp := range list {
 			found := false
 			for _, tps := range mesh.model.Triangles {
@@ -2254,14 +2511,14 @@ again:
 					if idp != tps[i] {
 						continue
 					}
-					if SamePoints(mesh.model.Points[idp], mesh.model.Points[tps[i]]) {
+					if tol.SamePoints(mesh.model.Points[idp], mesh.model.Points[tps[i]]) {
 						found = true
 					}
 				}
 				if found {
 					continue
 				}
-				res, lineIntersect, err := TriangleSplitByPoint(
+				res, lineIntersect, err := tol.TriangleSplitByPoint(
 					mesh.model.Points[idp],
 					mesh.model.Points[tps[0]],
 					mesh.model.Points[tps[1]],
@@ -2285,24 +2542,24 @@ again:
 			et := eTree.New("equal point index")
 			_ = et.Add(fmt.Errorf("idp = %d", idp1))
 			_ = et.Add(fmt.Errorf("list = %v", list))
-			err = et
+			err = errorTree{et}
 			return
 		}
-		if SamePoints(mesh.model.Points[idp1], mesh.model.Points[idp2]) {
+		if tol.SamePoints(mesh.model.Points[idp1], mesh.model.Points[idp2]) {
 			et := eTree.New("same point")
 			_ = et.Add(fmt.Errorf("idp = %d", idp1))
 			_ = et.Add(fmt.Errorf("list = %v", list))
-			err = et
+			err = errorTree{et}
 			return
 		}
+		// triangles with first point
+		trs := mesh.around(mesh.triangleWith(idp1), idp1)
+		sort.Ints(trs)
 		{
 			found := false
-			for _, tri := range mesh.model.Triangles {
-				if tri[0] == Removed {
-					continue
-				}
-				if (idp1 == tri[0] || idp1 == tri[1] || idp1 == tri[2]) &&
-					(idp2 == tri[0] || idp2 == tri[1] || idp2 == tri[2]) {
+			for _, tr := range trs {
+				tri := mesh.model.Triangles[tr]
+				if idp2 == tri[0] || idp2 == tri[1] || idp2 == tri[2] {
 					found = true
 					break
 				}
@@ -2313,16 +2570,14 @@ again:
 		}
 		{
 			found := false
-			for _, tri := range mesh.model.Triangles {
-				if tri[0] == Removed {
-					continue
-				}
+			for _, tr := range trs {
+				tri := mesh.model.Triangles[tr]
 				start := idp1
 				last := idp2
 				var pi []Point
 				var stA, stB State
 				if idp1 == tri[0] {
-					pi, stA, stB = LineLine(
+					pi, stA, stB = tol.LineLine(
 						mesh.model.Points[start],
 						mesh.model.Points[last],
 						mesh.model.Points[tri[1]],
@@ -2330,7 +2585,7 @@ again:
 					)
 				}
 				if idp1 == tri[1] {
-					pi, stA, stB = LineLine(
+					pi, stA, stB = tol.LineLine(
 						mesh.model.Points[start],
 						mesh.model.Points[last],
 						mesh.model.Points[tri[0]],
@@ -2338,7 +2593,7 @@ again:
 					)
 				}
 				if idp1 == tri[2] {
-					pi, stA, stB = LineLine(
+					pi, stA, stB = tol.LineLine(
 						mesh.model.Points[start],
 						mesh.model.Points[last],
 						mesh.model.Points[tri[0]],
@@ -2358,7 +2613,7 @@ again:
 						_ = et.Add(fmt.Errorf("len(list) = %d", len(list)))
 						_ = et.Add(fmt.Errorf("list = %v", list))
 						_ = et.Add(err)
-						err = et
+						err = errorTree{et}
 						return
 					}
 					list = append(list[:i], append([]int{idp}, list[i:]...)...)
@@ -2383,7 +2638,7 @@ again:
 			_ = et.Add(fmt.Errorf("len(list) = %d", len(list)))
 			_ = et.Add(fmt.Errorf("list = %v", list))
 			_ = et.Add(err)
-			err = et
+			err = errorTree{et}
 			return
 		}
 		list = append(list[:i], append([]int{idp}, list[i:]...)...)
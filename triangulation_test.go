@@ -290,8 +290,12 @@ func TestTriangulation(t *testing.T) {
 				}
 				dist = math.Max(dist, math.Abs(xmax-xmin)/10.0)
 			}
-			ts.model.Intersection()
-			ts.model.Split(dist)
+			if err := ts.model.Intersection(); err != nil {
+				t.Fatal(err)
+			}
+			if err := ts.model.Split(dist); err != nil {
+				t.Fatal(err)
+			}
 			ts.model.ArcsToLines()
 			if err := os.WriteFile(
 				filepath.Join("testdata", "."+ts.name+".model.dxf"),
@@ -411,3 +415,50 @@ func getInTriangles(size int) []Point {
 	}
 	return coords
 }
+
+func TestAddPointMaterial(t *testing.T) {
+	// two squares with different materials
+	var m Model
+	m.AddLine(Point{0, 0}, Point{2, 0}, 1)
+	m.AddLine(Point{2, 0}, Point{2, 1}, 1)
+	m.AddLine(Point{2, 1}, Point{0, 1}, 1)
+	m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+	mesh, err := New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Materials(); err != nil {
+		t.Fatal(err)
+	}
+	var mats []int
+	for _, p := range []Point{{0.5, 0.5}, {1.5, 0.5}} {
+		ms, err := mesh.GetMaterials(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mats = append(mats, ms[0])
+	}
+	if mats[0] == mats[1] {
+		t.Fatalf("same materials: %v", mats)
+	}
+	// new triangles have material of replaced triangles
+	for _, p := range []Point{{0.3, 0.4}, {1.6, 0.7}, {0.7, 0.2}} {
+		if _, err = mesh.AddPoint(p, Movable); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		c := mesh.model.Points[tr[0]].X + mesh.model.Points[tr[1]].X + mesh.model.Points[tr[2]].X
+		exp := mats[0]
+		if 3 < c {
+			exp = mats[1]
+		}
+		if tr[3] != exp {
+			t.Errorf("triangle %d: material %d is not %d", i, tr[3], exp)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package gog
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Severity is level of model issue
+type Severity int
+
+const (
+	// InfoSeverity is issue without influence on triangulation
+	InfoSeverity Severity = iota
+	// WarningSeverity is issue with possible not expected result of
+	// triangulation
+	WarningSeverity
+	// ErrorSeverity is issue with fail of triangulation
+	ErrorSeverity
+)
+
+var severityNames = [...]string{"info", "warning", "error"}
+
+// String return name of severity
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// MarshalText return name of severity
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parse name of severity
+func (s *Severity) UnmarshalText(text []byte) error {
+	for i, name := range severityNames {
+		if name == string(text) {
+			*s = Severity(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("not valid severity: %q", text)
+}
+
+// IssueKind is kind of model issue
+type IssueKind string
+
+// Kinds of model issues
+const (
+	OutOfRangeIndex       IssueKind = "out of range index"
+	RemovedElement        IssueKind = "removed element"
+	ZeroLengthLine        IssueKind = "zero length line"
+	DegenerateArc         IssueKind = "degenerate arc"
+	DegenerateTriangle    IssueKind = "degenerate triangle"
+	InvertedTriangle      IssueKind = "inverted triangle"
+	DuplicateElement      IssueKind = "duplicate element"
+	OverlappingLines      IssueKind = "overlapping lines"
+	UnusedPoint           IssueKind = "unused point"
+	NearlyCoincidentPoint IssueKind = "nearly coincident points"
+	OpenChain             IssueKind = "open chain"
+)
+
+// Issue is problem of model with indexes of elements
+type Issue struct {
+	Severity  Severity  `json:"severity"`
+	Kind      IssueKind `json:"kind"`
+	Points    []int     `json:"points,omitempty"`
+	Lines     []int     `json:"lines,omitempty"`
+	Arcs      []int     `json:"arcs,omitempty"`
+	Triangles []int     `json:"triangles,omitempty"`
+	Quadrs    []int     `json:"quadrs,omitempty"`
+}
+
+// String return description of issue
+func (is Issue) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-7s %s:", is.Severity, is.Kind)
+	for _, e := range []struct {
+		name    string
+		indexes []int
+	}{
+		{"lines", is.Lines},
+		{"arcs", is.Arcs},
+		{"triangles", is.Triangles},
+		{"quadrs", is.Quadrs},
+		{"points", is.Points},
+	} {
+		if 0 < len(e.indexes) {
+			fmt.Fprintf(&sb, " %s %v", e.name, e.indexes)
+		}
+	}
+	return sb.String()
+}
+
+// Report is result of model validation
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Valid return true, if report has not issues with `ErrorSeverity`
+func (r Report) Valid() bool {
+	for _, is := range r.Issues {
+		if is.Severity == ErrorSeverity {
+			return false
+		}
+	}
+	return true
+}
+
+// String return description of all issues
+func (r Report) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Issues: %d\n", len(r.Issues))
+	for _, is := range r.Issues {
+		fmt.Fprintf(&sb, "%s\n", is)
+	}
+	return sb.String()
+}
+
+// Validate return report of model issues before triangulation.
+// Nearly coincident points are points closer 1000 epsilon of model
+// tolerance, see `ValidateDistance`.
+func (m Model) Validate() Report {
+	return m.ValidateDistance(1000 * m.tolerance().eps())
+}
+
+// ValidateDistance return report of model issues before triangulation.
+// Nearly coincident points are points closer distance `d`.
+//
+// Triangles must be in clockwise order as in triangulation, other
+// triangles are inverted.
+func (m Model) ValidateDistance(d float64) (r Report) {
+	tol := m.tolerance()
+	add := func(is Issue) {
+		r.Issues = append(r.Issues, is)
+	}
+	// valid return true, if all indexes of element are valid
+	valid := func(kind string, i int, ids []int) bool {
+		bad, removed := false, false
+		for _, id := range ids {
+			switch {
+			case id == Removed:
+				removed = true
+			case id < 0 || len(m.Points) <= id:
+				bad = true
+			}
+		}
+		if !bad && !removed {
+			return true
+		}
+		is := Issue{Severity: ErrorSeverity, Kind: OutOfRangeIndex}
+		if !bad {
+			is = Issue{Severity: WarningSeverity, Kind: RemovedElement}
+		}
+		switch kind {
+		case "line":
+			is.Lines = []int{i}
+		case "arc":
+			is.Arcs = []int{i}
+		case "triangle":
+			is.Triangles = []int{i}
+		case "quadr":
+			is.Quadrs = []int{i}
+		}
+		add(is)
+		return false
+	}
+	// used points
+	used := make([]bool, len(m.Points))
+	use := func(ids ...int) {
+		for _, id := range ids {
+			used[id] = true
+		}
+	}
+	// sorted indexes of points as key of element
+	key := func(ids ...int) string {
+		s := append([]int(nil), ids...)
+		sort.Ints(s)
+		return fmt.Sprint(s)
+	}
+	// minimal rotation of indexes in both directions as key of element
+	cycle := func(ids ...int) string {
+		var best []int
+		for _, dir := range []int{1, len(ids) - 1} {
+			for start := range ids {
+				s := make([]int, len(ids))
+				for k := range s {
+					s[k] = ids[(start+k*dir)%len(ids)]
+				}
+				if best == nil || slices.Compare(s, best) < 0 {
+					best = s
+				}
+			}
+		}
+		return fmt.Sprint(best)
+	}
+
+	// lines
+	var lines []int // valid lines
+	{
+		same := map[string][]int{}
+		for i, l := range m.Lines {
+			if !valid("line", i, l[:2]) {
+				continue
+			}
+			use(l[0], l[1])
+			if l[0] == l[1] || tol.SamePoints(m.Points[l[0]], m.Points[l[1]]) {
+				add(Issue{
+					Severity: ErrorSeverity,
+					Kind:     ZeroLengthLine,
+					Lines:    []int{i},
+					Points:   []int{l[0], l[1]},
+				})
+				continue
+			}
+			lines = append(lines, i)
+			k := key(l[0], l[1])
+			same[k] = append(same[k], i)
+		}
+		for _, i := range lines {
+			if ids := same[key(m.Lines[i][0], m.Lines[i][1])]; 1 < len(ids) && ids[0] == i {
+				add(Issue{Severity: WarningSeverity, Kind: DuplicateElement, Lines: ids})
+			}
+		}
+	}
+	// arcs
+	var arcs []int // valid arcs
+	{
+		same := map[string][]int{}
+		for i, a := range m.Arcs {
+			if !valid("arc", i, a[:3]) {
+				continue
+			}
+			use(a[0], a[1], a[2])
+			p0, p1, p2 := m.Points[a[0]], m.Points[a[1]], m.Points[a[2]]
+			if tol.SamePoints(p0, p1) || tol.SamePoints(p1, p2) || tol.SamePoints(p0, p2) ||
+				tol.Orientation(p0, p1, p2) == CollinearPoints {
+				add(Issue{
+					Severity: ErrorSeverity,
+					Kind:     DegenerateArc,
+					Arcs:     []int{i},
+					Points:   []int{a[0], a[1], a[2]},
+				})
+				continue
+			}
+			arcs = append(arcs, i)
+			k := key(a[0], a[1], a[2])
+			same[k] = append(same[k], i)
+		}
+		for _, i := range arcs {
+			if ids := same[key(m.Arcs[i][:3]...)]; 1 < len(ids) && ids[0] == i {
+				add(Issue{Severity: WarningSeverity, Kind: DuplicateElement, Arcs: ids})
+			}
+		}
+	}
+	// triangles
+	{
+		same := map[string][]int{}
+		var trs []int
+		for i, t := range m.Triangles {
+			if !valid("triangle", i, t[:3]) {
+				continue
+			}
+			use(t[0], t[1], t[2])
+			switch tol.Orientation(m.Points[t[0]], m.Points[t[1]], m.Points[t[2]]) {
+			case CollinearPoints:
+				add(Issue{
+					Severity:  ErrorSeverity,
+					Kind:      DegenerateTriangle,
+					Triangles: []int{i},
+					Points:    []int{t[0], t[1], t[2]},
+				})
+				continue
+			case CounterClockwisePoints:
+				add(Issue{
+					Severity:  WarningSeverity,
+					Kind:      InvertedTriangle,
+					Triangles: []int{i},
+				})
+			}
+			trs = append(trs, i)
+			k := key(t[0], t[1], t[2])
+			same[k] = append(same[k], i)
+		}
+		for _, i := range trs {
+			if ids := same[key(m.Triangles[i][:3]...)]; 1 < len(ids) && ids[0] == i {
+				add(Issue{Severity: WarningSeverity, Kind: DuplicateElement, Triangles: ids})
+			}
+		}
+	}
+	// quadrs
+	{
+		same := map[string][]int{}
+		var qs []int
+		for i, q := range m.Quadrs {
+			if !valid("quadr", i, q[:4]) {
+				continue
+			}
+			use(q[0], q[1], q[2], q[3])
+			qs = append(qs, i)
+			k := cycle(q[0], q[1], q[2], q[3])
+			same[k] = append(same[k], i)
+		}
+		for _, i := range qs {
+			if ids := same[cycle(m.Quadrs[i][:4]...)]; 1 < len(ids) && ids[0] == i {
+				add(Issue{Severity: WarningSeverity, Kind: DuplicateElement, Quadrs: ids})
+			}
+		}
+	}
+	// overlapping collinear lines
+	{
+		boxes := make([]box, len(lines))
+		for k, i := range lines {
+			boxes[k] = boxOf(m.Points[m.Lines[i][0]], m.Points[m.Lines[i][1]])
+		}
+		tree := newRtree(boxes)
+		for k, i := range lines {
+			for _, n := range tree.search(boxes[k]) {
+				j := lines[n]
+				if j <= i || key(m.Lines[i][:2]...) == key(m.Lines[j][:2]...) {
+					continue
+				}
+				if overlapLines(tol,
+					m.Points[m.Lines[i][0]], m.Points[m.Lines[i][1]],
+					m.Points[m.Lines[j][0]], m.Points[m.Lines[j][1]],
+				) {
+					add(Issue{Severity: WarningSeverity, Kind: OverlappingLines, Lines: []int{i, j}})
+				}
+			}
+		}
+	}
+	// unused points
+	{
+		var ids []int
+		for i := range used {
+			if !used[i] {
+				ids = append(ids, i)
+			}
+		}
+		if 0 < len(ids) {
+			add(Issue{Severity: InfoSeverity, Kind: UnusedPoint, Points: ids})
+		}
+	}
+	// nearly coincident points
+	{
+		boxes := make([]box, len(m.Points))
+		for i, p := range m.Points {
+			boxes[i] = boxOf(p)
+		}
+		tree := newRtree(boxes)
+		for i, p := range m.Points {
+			near := boxOf(Point{X: p.X - d, Y: p.Y - d}, Point{X: p.X + d, Y: p.Y + d})
+			for _, j := range tree.search(near) {
+				if i < j && Distance(p, m.Points[j]) < d {
+					add(Issue{
+						Severity: WarningSeverity,
+						Kind:     NearlyCoincidentPoint,
+						Points:   []int{i, j},
+					})
+				}
+			}
+		}
+	}
+	// open chains of lines and arcs
+	{
+		type edge struct {
+			arc   bool
+			index int
+			a, b  int // indexes of end points
+		}
+		var edges []edge
+		for _, i := range lines {
+			edges = append(edges, edge{index: i, a: m.Lines[i][0], b: m.Lines[i][1]})
+		}
+		for _, i := range arcs {
+			edges = append(edges, edge{arc: true, index: i, a: m.Arcs[i][0], b: m.Arcs[i][2]})
+		}
+		near := map[int][]int{} // edges of point
+		for e := range edges {
+			near[edges[e].a] = append(near[edges[e].a], e)
+			near[edges[e].b] = append(near[edges[e].b], e)
+		}
+		visited := make([]bool, len(edges))
+		for p := range m.Points {
+			if len(near[p]) != 1 || visited[near[p][0]] {
+				continue
+			}
+			// walk from free end of chain
+			is := Issue{Severity: WarningSeverity, Kind: OpenChain}
+			for e, end := near[p][0], p; ; {
+				visited[e] = true
+				if edges[e].arc {
+					is.Arcs = append(is.Arcs, edges[e].index)
+				} else {
+					is.Lines = append(is.Lines, edges[e].index)
+				}
+				if end == edges[e].a {
+					end = edges[e].b
+				} else {
+					end = edges[e].a
+				}
+				if len(near[end]) != 2 {
+					is.Points = []int{p, end}
+					break
+				}
+				next := near[end][0]
+				if next == e {
+					next = near[end][1]
+				}
+				if visited[next] {
+					is.Points = []int{p, end}
+					break
+				}
+				e = next
+			}
+			add(is)
+		}
+	}
+	return
+}
+
+// overlapLines return true for collinear lines with common part longer
+// epsilon of tolerance
+func overlapLines(tol Tolerance, pa0, pa1, pb0, pb1 Point) bool {
+	if tol.Orientation(pa0, pa1, pb0) != CollinearPoints ||
+		tol.Orientation(pa0, pa1, pb1) != CollinearPoints {
+		return false
+	}
+	// projection on line A
+	var (
+		dx, dy = pa1.X - pa0.X, pa1.Y - pa0.Y
+		length = math.Hypot(dx, dy)
+		proj   = func(p Point) float64 {
+			return ((p.X-pa0.X)*dx + (p.Y-pa0.Y)*dy) / length
+		}
+		t0, t1 = proj(pb0), proj(pb1)
+	)
+	return tol.eps() < math.Min(length, math.Max(t0, t1))-math.Max(0, math.Min(t0, t1))
+}
@@ -0,0 +1,77 @@
+package gog
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestValidate(t *testing.T) {
+	// valid model
+	var m Model
+	m.AddLine(Point{0, 0}, Point{2, 0}, 1)
+	m.AddLine(Point{2, 0}, Point{2, 2}, 1)
+	m.AddArc(Point{2, 2}, Point{1, 2.5}, Point{0, 2}, 1)
+	m.AddLine(Point{0, 2}, Point{0, 0}, 1)
+	m.AddTriangle(Point{0, 0}, Point{0, 2}, Point{2, 2}, 2)
+	if r := m.Validate(); len(r.Issues) != 0 || !r.Valid() {
+		t.Fatalf("not valid model:\n%s", r)
+	}
+	// model with issues
+	m.Points = append(m.Points,
+		Point{5, 5},         // 5: unused point
+		Point{1, 1e-11},     // 6: nearly coincident with 7, 11
+		Point{1, 2e-11},     // 7
+		Point{3, 0},         // 8
+		Point{3, 1},         // 9
+		Point{3, 3},         // 10
+		Point{1 + 1e-12, 0}, // 11
+	)
+	m.Lines = append(m.Lines,
+		[3]int{1, 0, 3},         // 3: duplicate of 0
+		[3]int{8, 8, 1},         // 4: zero length
+		[3]int{0, 100, 1},       // 5: out of range
+		[3]int{Removed, 1, 1},   // 6: removed
+		[3]int{10, 9, 1},        // 7: open chain
+		[3]int{9, 8, 1},         // 8
+		[3]int{8, 11, 1},        // 9: overlapping with 0
+		[3]int{6, 7, 1},         // 10: zero length by tolerance
+		[3]int{11, 1, 1},        // 11: overlapping with 0, 9
+		[3]int{-5, Removed, -5}, // 12: out of range
+	)
+	m.Arcs = append(m.Arcs,
+		[4]int{8, 9, 10, 1}, // 1: degenerate arc
+	)
+	m.Triangles = append(m.Triangles,
+		[4]int{0, 1, 2, 2},  // 1: inverted triangle
+		[4]int{0, 11, 1, 2}, // 2: degenerate triangle
+		[4]int{2, 4, 0, 2},  // 3: duplicate of 0
+	)
+	m.Quadrs = append(m.Quadrs,
+		[5]int{0, 1, 2, 4, 1},
+		[5]int{1, 2, 4, 0, 1},
+		[5]int{4, 2, 1, 0, 1}, // duplicate in reversed order
+		[5]int{0, 2, 1, 4, 1}, // not duplicate with other order of points
+	)
+	r := m.ValidateDistance(1e-6)
+	if r.Valid() {
+		t.Errorf("model with errors is valid")
+	}
+	compare.Test(t, filepath.Join("testdata", "Validate"), []byte(r.String()))
+
+	// machine-readable report
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var u Report
+	if err = json.Unmarshal(b, &u); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, u) {
+		t.Errorf("not same reports:\n%s\n%s", r, u)
+	}
+}
@@ -0,0 +1,343 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// VTKField is user field of points or cells. Only one of values
+// Scalars or Vectors must be not nil. Amount of values must be same
+// as amount of points or cells.
+type VTKField struct {
+	// Name of field without spaces
+	Name string
+
+	// Scalars is scalar value for each point or cell
+	Scalars []float64
+
+	// Vectors is vector value for each point or cell
+	Vectors []Point
+}
+
+// VTKOptions is options of vtk and vtu files
+type VTKOptions struct {
+	// PointData is user fields of points
+	PointData []VTKField
+
+	// CellData is user fields of cells.
+	// Cells are not removed triangles and after that not removed quadrs
+	// of model. Amount of values is amount of cells or amount of all
+	// triangles and quadrs of model, then values of removed elements
+	// are ignored.
+	CellData []VTKField
+}
+
+// vtkArray is data array of vtk file
+type vtkArray struct {
+	name    string
+	ints    []int     // integer scalars
+	floats  []float64 // float scalars
+	vectors []Point
+}
+
+// size return amount of values in array
+func (a vtkArray) size() int {
+	switch {
+	case a.ints != nil:
+		return len(a.ints)
+	case a.floats != nil:
+		return len(a.floats)
+	}
+	return len(a.vectors)
+}
+
+// vtk types of cells
+const (
+	vtkTriangle = 5
+	vtkQuadr    = 9
+)
+
+// vtkGrid is unstructured grid of vtk file
+type vtkGrid struct {
+	points []Point
+	cells  [][]int
+	types  []int
+	pdata  []vtkArray
+	cdata  []vtkArray
+}
+
+// vtkGrid return unstructured grid of model. Array of tags is first array
+// of cell data. Arrays of point data are added before user fields.
+func (m Model) vtkGrid(opts VTKOptions, pdata ...vtkArray) (g vtkGrid, err error) {
+	g.points = m.Points
+	tags := vtkArray{name: "tag", ints: []int{}}
+	var alive []bool // not removed triangles and quadrs
+	for _, tr := range m.Triangles {
+		alive = append(alive, tr[0] != Removed)
+		if tr[0] == Removed {
+			continue
+		}
+		g.cells = append(g.cells, tr[:3])
+		g.types = append(g.types, vtkTriangle)
+		tags.ints = append(tags.ints, tr[3])
+	}
+	for _, q := range m.Quadrs {
+		alive = append(alive, q[0] != Removed)
+		if q[0] == Removed {
+			continue
+		}
+		g.cells = append(g.cells, q[:4])
+		g.types = append(g.types, vtkQuadr)
+		tags.ints = append(tags.ints, q[4])
+	}
+	g.cdata = append(g.cdata, tags)
+	g.pdata = append(g.pdata, pdata...)
+
+	add := func(list *[]vtkArray, fields []VTKField, size int, prefix string, alive []bool) error {
+		for _, f := range fields {
+			if f.Name == "" || strings.ContainsAny(f.Name, " \t\n\"<>&") {
+				return fmt.Errorf("not valid name of %s field: `%s`", prefix, f.Name)
+			}
+			for _, a := range *list {
+				if a.name == f.Name {
+					return fmt.Errorf("duplicate name of %s field: `%s`", prefix, f.Name)
+				}
+			}
+			a := vtkArray{name: f.Name}
+			switch {
+			case f.Scalars != nil && f.Vectors == nil:
+				a.floats = f.Scalars
+			case f.Scalars == nil && f.Vectors != nil:
+				a.vectors = f.Vectors
+			default:
+				return fmt.Errorf("%s field `%s` must have scalars or vectors", prefix, f.Name)
+			}
+			if a.size() != size && a.size() == len(alive) {
+				// ignore values of removed elements
+				floats, vectors := a.floats, a.vectors
+				if floats != nil {
+					a.floats = []float64{}
+				} else {
+					a.vectors = []Point{}
+				}
+				for i, ok := range alive {
+					switch {
+					case !ok:
+					case floats != nil:
+						a.floats = append(a.floats, floats[i])
+					default:
+						a.vectors = append(a.vectors, vectors[i])
+					}
+				}
+			}
+			if a.size() != size {
+				return fmt.Errorf("%s field `%s` have %d values, but expected %d",
+					prefix, f.Name, a.size(), size)
+			}
+			*list = append(*list, a)
+		}
+		return nil
+	}
+	if err = add(&g.pdata, opts.PointData, len(g.points), "point", nil); err != nil {
+		return
+	}
+	if err = add(&g.cdata, opts.CellData, len(g.cells), "cell", alive); err != nil {
+		return
+	}
+	return
+}
+
+// vtkFloat return float value in vtk format
+func vtkFloat(v float64) string {
+	if v == 0 {
+		// ignore negative zero
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// legacy write grid in vtk legacy ASCII format
+func (g vtkGrid) legacy(w io.Writer) (err error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# vtk DataFile Version 3.0\n")
+	fmt.Fprintf(&buf, "gog\n")
+	fmt.Fprintf(&buf, "ASCII\n")
+	fmt.Fprintf(&buf, "DATASET UNSTRUCTURED_GRID\n")
+	fmt.Fprintf(&buf, "POINTS %d double\n", len(g.points))
+	for _, p := range g.points {
+		fmt.Fprintf(&buf, "%s %s 0\n", vtkFloat(p.X), vtkFloat(p.Y))
+	}
+	size := 0
+	for _, c := range g.cells {
+		size += len(c) + 1
+	}
+	fmt.Fprintf(&buf, "CELLS %d %d\n", len(g.cells), size)
+	for _, c := range g.cells {
+		fmt.Fprintf(&buf, "%d", len(c))
+		for _, p := range c {
+			fmt.Fprintf(&buf, " %d", p)
+		}
+		fmt.Fprintf(&buf, "\n")
+	}
+	fmt.Fprintf(&buf, "CELL_TYPES %d\n", len(g.types))
+	for _, t := range g.types {
+		fmt.Fprintf(&buf, "%d\n", t)
+	}
+	data := func(arrays []vtkArray) {
+		for _, a := range arrays {
+			switch {
+			case a.ints != nil:
+				fmt.Fprintf(&buf, "SCALARS %s int 1\nLOOKUP_TABLE default\n", a.name)
+				for _, v := range a.ints {
+					fmt.Fprintf(&buf, "%d\n", v)
+				}
+			case a.floats != nil:
+				fmt.Fprintf(&buf, "SCALARS %s double 1\nLOOKUP_TABLE default\n", a.name)
+				for _, v := range a.floats {
+					fmt.Fprintf(&buf, "%s\n", vtkFloat(v))
+				}
+			default:
+				fmt.Fprintf(&buf, "VECTORS %s double\n", a.name)
+				for _, v := range a.vectors {
+					fmt.Fprintf(&buf, "%s %s 0\n", vtkFloat(v.X), vtkFloat(v.Y))
+				}
+			}
+		}
+	}
+	fmt.Fprintf(&buf, "CELL_DATA %d\n", len(g.cells))
+	data(g.cdata)
+	if 0 < len(g.pdata) {
+		fmt.Fprintf(&buf, "POINT_DATA %d\n", len(g.points))
+		data(g.pdata)
+	}
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// xml write grid in vtu XML format
+func (g vtkGrid) xml(w io.Writer) (err error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<?xml version=\"1.0\"?>\n")
+	fmt.Fprintf(&buf, "<VTKFile type=\"UnstructuredGrid\" version=\"0.1\" byte_order=\"LittleEndian\">\n")
+	fmt.Fprintf(&buf, "<UnstructuredGrid>\n")
+	fmt.Fprintf(&buf, "<Piece NumberOfPoints=\"%d\" NumberOfCells=\"%d\">\n",
+		len(g.points), len(g.cells))
+	array := func(a vtkArray) {
+		switch {
+		case a.ints != nil:
+			fmt.Fprintf(&buf, "<DataArray type=\"Int32\" Name=\"%s\" format=\"ascii\">\n", a.name)
+			for _, v := range a.ints {
+				fmt.Fprintf(&buf, "%d\n", v)
+			}
+		case a.floats != nil:
+			fmt.Fprintf(&buf, "<DataArray type=\"Float64\" Name=\"%s\" format=\"ascii\">\n", a.name)
+			for _, v := range a.floats {
+				fmt.Fprintf(&buf, "%s\n", vtkFloat(v))
+			}
+		default:
+			fmt.Fprintf(&buf, "<DataArray type=\"Float64\" Name=\"%s\" "+
+				"NumberOfComponents=\"3\" format=\"ascii\">\n", a.name)
+			for _, v := range a.vectors {
+				fmt.Fprintf(&buf, "%s %s 0\n", vtkFloat(v.X), vtkFloat(v.Y))
+			}
+		}
+		fmt.Fprintf(&buf, "</DataArray>\n")
+	}
+	fmt.Fprintf(&buf, "<PointData>\n")
+	for _, a := range g.pdata {
+		array(a)
+	}
+	fmt.Fprintf(&buf, "</PointData>\n")
+	fmt.Fprintf(&buf, "<CellData>\n")
+	for _, a := range g.cdata {
+		array(a)
+	}
+	fmt.Fprintf(&buf, "</CellData>\n")
+	fmt.Fprintf(&buf, "<Points>\n")
+	array(vtkArray{name: "Points", vectors: g.points})
+	fmt.Fprintf(&buf, "</Points>\n")
+	fmt.Fprintf(&buf, "<Cells>\n")
+	var (
+		connectivity = vtkArray{name: "connectivity", ints: []int{}}
+		offsets      = vtkArray{name: "offsets", ints: []int{}}
+	)
+	for _, c := range g.cells {
+		connectivity.ints = append(connectivity.ints, c...)
+		offsets.ints = append(offsets.ints, len(connectivity.ints))
+	}
+	array(connectivity)
+	array(offsets)
+	fmt.Fprintf(&buf, "<DataArray type=\"UInt8\" Name=\"types\" format=\"ascii\">\n")
+	for _, t := range g.types {
+		fmt.Fprintf(&buf, "%d\n", t)
+	}
+	fmt.Fprintf(&buf, "</DataArray>\n")
+	fmt.Fprintf(&buf, "</Cells>\n")
+	fmt.Fprintf(&buf, "</Piece>\n")
+	fmt.Fprintf(&buf, "</UnstructuredGrid>\n")
+	fmt.Fprintf(&buf, "</VTKFile>\n")
+	_, err = buf.WriteTo(w)
+	return
+}
+
+// WriteVTK write model in vtk legacy ASCII format. Cells are triangles
+// and quadrs with cell data `tag`.
+//
+// https://vtk.org/wp-content/uploads/2015/04/file-formats.pdf
+func (m Model) WriteVTK(w io.Writer, opts VTKOptions) (err error) {
+	g, err := m.vtkGrid(opts)
+	if err != nil {
+		return
+	}
+	return g.legacy(w)
+}
+
+// WriteVTU write model in vtu XML unstructured grid format. Cells are
+// triangles and quadrs with cell data `tag`.
+//
+// https://vtk.org/wp-content/uploads/2015/04/file-formats.pdf
+func (m Model) WriteVTU(w io.Writer, opts VTKOptions) (err error) {
+	g, err := m.vtkGrid(opts)
+	if err != nil {
+		return
+	}
+	return g.xml(w)
+}
+
+// vtkGrid return unstructured grid of mesh triangles with point data `state`
+func (mesh *Mesh) vtkGrid(opts VTKOptions) (g vtkGrid, err error) {
+	m := Model{Points: mesh.model.Points}
+	for _, tr := range mesh.model.Triangles {
+		if tr[0] == Removed {
+			continue
+		}
+		m.Triangles = append(m.Triangles, tr)
+	}
+	state := vtkArray{name: "state", ints: append([]int{}, mesh.Points...)}
+	return m.vtkGrid(opts, state)
+}
+
+// WriteVTK write mesh in vtk legacy ASCII format. Cells are triangles
+// with cell data `tag` as material. Point data `state` is state of point
+// Fixed or Movable.
+func (mesh *Mesh) WriteVTK(w io.Writer, opts VTKOptions) (err error) {
+	g, err := mesh.vtkGrid(opts)
+	if err != nil {
+		return
+	}
+	return g.legacy(w)
+}
+
+// WriteVTU write mesh in vtu XML unstructured grid format. Cells are
+// triangles with cell data `tag` as material. Point data `state` is state
+// of point Fixed or Movable.
+func (mesh *Mesh) WriteVTU(w io.Writer, opts VTKOptions) (err error) {
+	g, err := mesh.vtkGrid(opts)
+	if err != nil {
+		return
+	}
+	return g.xml(w)
+}
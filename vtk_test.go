@@ -0,0 +1,126 @@
+package gog
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Konstantin8105/compare"
+)
+
+func TestVTK(t *testing.T) {
+	var m Model
+	m.AddLine(Point{-1, 0}, Point{1, 0}, 3)
+	m.AddTriangle(Point{5, 0}, Point{6, 0}, Point{5, 1}, 4)
+	m.Quadrs = append(m.Quadrs, [5]int{
+		m.AddPoint(Point{7, 0}),
+		m.AddPoint(Point{8, 0}),
+		m.AddPoint(Point{8, 1}),
+		m.AddPoint(Point{7, 1}),
+		5,
+	})
+	var temperature []float64
+	var displacement []Point
+	for i, p := range m.Points {
+		temperature = append(temperature, float64(i)*0.5)
+		displacement = append(displacement, Point{X: p.Y, Y: -p.X})
+	}
+	opts := VTKOptions{
+		PointData: []VTKField{
+			{Name: "temperature", Scalars: temperature},
+			{Name: "displacement", Vectors: displacement},
+		},
+		CellData: []VTKField{
+			{Name: "stress", Scalars: []float64{1.5, -2}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := m.WriteVTK(&buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	compare.Test(t, filepath.Join("testdata", "WriteVTK"), buf.Bytes())
+	buf.Reset()
+	if err := m.WriteVTU(&buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	compare.Test(t, filepath.Join("testdata", "WriteVTU"), buf.Bytes())
+}
+
+func TestVTKMesh(t *testing.T) {
+	var m Model
+	m.AddLine(Point{0, 0}, Point{1, 0}, 1)
+	m.AddLine(Point{1, 0}, Point{1, 1}, 1)
+	m.AddLine(Point{1, 1}, Point{0, 1}, 1)
+	m.AddLine(Point{0, 1}, Point{0, 0}, 1)
+	mesh, err := New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mesh.Materials(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := mesh.WriteVTK(&buf, VTKOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	compare.Test(t, filepath.Join("testdata", "WriteVTKMesh"), buf.Bytes())
+	buf.Reset()
+	if err := mesh.WriteVTU(&buf, VTKOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	compare.Test(t, filepath.Join("testdata", "WriteVTUMesh"), buf.Bytes())
+}
+
+func TestVTKRemoved(t *testing.T) {
+	var m Model
+	m.AddTriangle(Point{0, 0}, Point{1, 0}, Point{0, 1}, 1)
+	m.AddTriangle(Point{1, 0}, Point{1, 1}, Point{0, 1}, 2)
+	m.AddTriangle(Point{1, 0}, Point{2, 0}, Point{1, 1}, 3)
+	m.Triangles[1][0] = Removed
+	// values for cells and for all triangles of model
+	var outputs []string
+	for _, values := range []struct {
+		scalars []float64
+		vectors []Point
+	}{
+		{[]float64{1.5, 3.5}, []Point{{1, 1}, {3, 3}}},
+		{[]float64{1.5, 2.5, 3.5}, []Point{{1, 1}, {2, 2}, {3, 3}}},
+	} {
+		var buf bytes.Buffer
+		if err := m.WriteVTK(&buf, VTKOptions{CellData: []VTKField{
+			{Name: "stress", Scalars: values.scalars},
+			{Name: "flux", Vectors: values.vectors},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, buf.String())
+	}
+	if outputs[0] != outputs[1] {
+		t.Errorf("not same outputs:\n%s\n%s", outputs[0], outputs[1])
+	}
+}
+
+func TestVTKError(t *testing.T) {
+	var m Model
+	m.AddTriangle(Point{0, 0}, Point{1, 0}, Point{0, 1}, 1)
+	for i, opts := range []VTKOptions{
+		{PointData: []VTKField{{Name: "", Scalars: []float64{1, 2, 3}}}},
+		{PointData: []VTKField{{Name: "a b", Scalars: []float64{1, 2, 3}}}},
+		{PointData: []VTKField{{Name: "a", Scalars: []float64{1, 2}}}},
+		{PointData: []VTKField{{Name: "a"}}},
+		{CellData: []VTKField{{Name: "tag", Scalars: []float64{1}}}},
+		{CellData: []VTKField{{Name: "a", Vectors: []Point{{}, {}}}}},
+	} {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if err := m.WriteVTK(&bytes.Buffer{}, opts); err == nil {
+				t.Fatalf("error is not found")
+			} else {
+				t.Logf("%v", err)
+			}
+			if err := m.WriteVTU(&bytes.Buffer{}, opts); err == nil {
+				t.Fatalf("error is not found")
+			}
+		})
+	}
+}